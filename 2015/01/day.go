@@ -0,0 +1,36 @@
+package day01
+
+import (
+	"github.com/lindeneg/aoc/cl"
+)
+
+const (
+	Up   = 1
+	Down = -1
+)
+
+var moves = map[byte]int{
+	'(': Up,
+	')': Down,
+}
+
+func init() {
+	d := cl.NewDay(2015, 1, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", -1).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 5).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	ans := 0
+	for i, v := range input.B {
+		ans += moves[v]
+		if ans == Down && part2 {
+			return i + 1
+		}
+	}
+	return ans
+}
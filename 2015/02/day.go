@@ -0,0 +1,39 @@
+package day02
+
+import "github.com/lindeneg/aoc/cl"
+
+func init() {
+	d := cl.NewDay(2015, 2, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 101).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 48).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	ans := 0
+	for _, v := range input.R1 {
+		b := cl.MustParse[box](v, "{l}x{w}x{h}")
+		l, w, h := b.L, b.W, b.H
+		if part2 {
+			ans += totalRibbon(l, w, h)
+		} else {
+			ans += totalWrappingPaper(l, w, h)
+		}
+	}
+	return ans
+}
+
+type box struct {
+	L, W, H int
+}
+
+func totalWrappingPaper(l, w, h int) int {
+	return 2*l*w + 2*w*h + 2*h*l + min(l*w, w*h, h*l)
+}
+
+func totalRibbon(l, w, h int) int {
+	return 2*min(l+w, w+h, h+l) + l*w*h
+}
@@ -0,0 +1,34 @@
+package day03
+
+import (
+	"github.com/lindeneg/aoc/cl"
+)
+
+func init() {
+	d := cl.NewDay(2015, 3, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 2).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 11).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	pos := cl.V2(0, 0)
+	posAlt := cl.V2(0, 0)
+	houses := cl.NewSparseGrid[int]()
+	houses.Set(pos, 1)
+	for i, v := range input.B {
+		var p cl.Vec2
+		if i%2 > 0 && part2 {
+			posAlt = posAlt.Move(cl.DirOf(v))
+			p = posAlt
+		} else {
+			pos = pos.Move(cl.DirOf(v))
+			p = pos
+		}
+		houses.Set(p, houses.At(p)+1)
+	}
+	return houses.Len()
+}
@@ -1,4 +1,4 @@
-package main
+package day04
 
 import (
 	"bytes"
@@ -18,34 +18,25 @@ var (
 
 // TODO optimize this, runs in about a second..
 
-func main() {
-	example := cl.NewInputS("example.in")
-	cl.Example(
-		cl.Ex[int]{
-			Want: 1048970,
-			Fn:   func() int { return puzzle(example, false) },
-		},
-		cl.Ex[int]{
-			Want: 5714438,
-			Fn:   func() int { return puzzle(example, true) },
-		},
-	)
-	input := cl.NewInput("puzzle.in")
-	cl.Puzzle(
-		cl.Ex[int]{
-			Want: 282749,
-			Fn:   func() int { return puzzle(input, false) },
-		},
-		cl.Ex[int]{
-			Want: 9962624,
-			Fn:   func() int { return puzzle(input, true) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2015, 4, cl.Lines)
+	cl.SolveCtx(d, 1, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, false) }).
+		Example("example.in", 1048970).
+		Puzzle()
+	cl.SolveCtx(d, 2, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, true) }).
+		Example("example.in", 5714438).
+		Puzzle()
 }
 
-func puzzle(input cl.Input, part2 bool) int {
+func puzzle(ctx *cl.Ctx, input cl.Input, part2 bool) int {
 	ans := 0
 	for ans < limit {
+		if ans%(1<<16) == 0 {
+			if ctx.Err() != nil {
+				return -1
+			}
+			ctx.Progress(ans, 0)
+		}
 		b := bytes.NewBuffer(input.B)
 		b.WriteString(strconv.Itoa(ans))
 		h := md5Hash(b.Bytes())
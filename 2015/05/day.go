@@ -1,4 +1,4 @@
-package main
+package day05
 
 import (
 	"regexp"
@@ -17,30 +17,14 @@ var (
 	}
 )
 
-func main() {
-	example1 := cl.NewInput("example1.in")
-	example2 := cl.NewInput("example2.in")
-	cl.Example(
-		cl.Ex[int]{
-			Want: 2,
-			Fn:   func() int { return puzzle(example1, false) },
-		},
-		cl.Ex[int]{
-			Want: 2,
-			Fn:   func() int { return puzzle(example2, true) },
-		},
-	)
-	input := cl.NewInput("puzzle.in")
-	cl.Puzzle(
-		cl.Ex[int]{
-			Want: 258,
-			Fn:   func() int { return puzzle(input, false) },
-		},
-		cl.Ex[int]{
-			Want: 53,
-			Fn:   func() int { return puzzle(input, true) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2015, 5, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example1.in", 2).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example2.in", 2).
+		Puzzle()
 }
 
 func puzzle(input cl.Input, part2 bool) int {
@@ -1,7 +1,6 @@
-package main
+package day06
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/lindeneg/aoc/cl"
@@ -15,29 +14,14 @@ const (
 
 var ops = []string{"turn on", "turn off", "toggle"}
 
-func main() {
-	example := cl.NewInput("example.in")
-	cl.Example(
-		cl.Ex[int]{
-			Want: 998996,
-			Fn:   func() int { return puzzle(example, false) },
-		},
-		cl.Ex[int]{
-			Want: 1001996,
-			Fn:   func() int { return puzzle(example, true) },
-		},
-	)
-	input := cl.NewInput("puzzle.in")
-	cl.Puzzle(
-		cl.Ex[int]{
-			Want: 569999,
-			Fn:   func() int { return puzzle(input, false) },
-		},
-		cl.Ex[int]{
-			Want: 17836115,
-			Fn:   func() int { return puzzle(input, true) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2015, 6, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 998996).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 1001996).
+		Puzzle()
 }
 
 func puzzle(input cl.Input, part2 bool) int {
@@ -48,65 +32,64 @@ func puzzle(input cl.Input, part2 bool) int {
 	return g.count
 }
 
+// grid keeps the lights dense. Every instruction touches up to a
+// million of them, which a SparseGrid's map makes about 70 times
+// slower.
 type grid struct {
-	grid  [][]int
+	grid  cl.Grid[int]
 	count int
 	part2 bool
 }
 
 func makeGrid(rows, cols int, part2 bool) *grid {
-	g := make([][]int, rows)
-	for i := range g {
-		g[i] = make([]int, cols)
-	}
-	return &grid{grid: g, part2: part2}
+	return &grid{grid: cl.NewGrid[int](cols, rows), part2: part2}
 }
 
 func (g *grid) on(v cl.Vec2) {
-	p := g.grid[v.Y][v.X]
+	p := g.grid.At(v)
 	if g.part2 {
-		g.grid[v.Y][v.X]++
+		g.grid.Set(v, p+1)
 		g.count++
 		return
 	}
-	g.grid[v.Y][v.X] = 1
+	g.grid.Set(v, 1)
 	if p == 0 {
 		g.count++
 	}
 }
 func (g *grid) off(v cl.Vec2) {
-	p := g.grid[v.Y][v.X]
+	p := g.grid.At(v)
 	if g.part2 {
 		if p > 0 {
-			g.grid[v.Y][v.X]--
+			g.grid.Set(v, p-1)
 			g.count--
 		}
 		return
 	}
-	g.grid[v.Y][v.X] = 0
+	g.grid.Set(v, 0)
 	if p == 1 {
 		g.count--
 	}
 }
 func (g *grid) toggle(v cl.Vec2) {
-	p := g.grid[v.Y][v.X]
+	p := g.grid.At(v)
 	if g.part2 {
-		g.grid[v.Y][v.X] += 2
+		g.grid.Set(v, p+2)
 		g.count += 2
 		return
 	}
 	if p == 1 {
-		g.grid[v.Y][v.X] = 0
+		g.grid.Set(v, 0)
 		g.count--
 	} else {
-		g.grid[v.Y][v.X] = 1
+		g.grid.Set(v, 1)
 		g.count++
 	}
 }
 
 func (g *grid) apply(op Op) {
-	for y := op.from.Y; y <= op.to.Y; y++ {
-		for x := op.from.X; x <= op.to.X; x++ {
+	for y := op.From.Y; y <= op.To.Y; y++ {
+		for x := op.From.X; x <= op.To.X; x++ {
 			switch op.op {
 			case TurnOn:
 				g.on(cl.V2(x, y))
@@ -120,19 +103,20 @@ func (g *grid) apply(op Op) {
 }
 
 type Op struct {
-	from, to cl.Vec2
+	From, To cl.Vec2
 	op       int
 }
 
 func parseOp(s string) Op {
-	var o Op
+	var n int
 	for i, op := range ops {
 		if strings.HasPrefix(s, op) {
 			s = strings.TrimSpace(strings.TrimPrefix(s, op))
-			o.op = i
+			n = i
 			break
 		}
 	}
-	fmt.Sscanf(s, "%d,%d through %d,%d", &o.from.X, &o.from.Y, &o.to.X, &o.to.Y)
+	o := cl.MustParse[Op](s, "{from.x},{from.y} through {to.x},{to.y}")
+	o.op = n
 	return o
 }
@@ -1,4 +1,4 @@
-package main
+package day07
 
 import (
 	"strconv"
@@ -7,25 +7,13 @@ import (
 	"github.com/lindeneg/aoc/cl"
 )
 
-func main() {
-	example := cl.NewInput("example.in")
-	cl.Example(
-		cl.Ex[int]{
-			Want: 65079,
-			Fn:   func() int { return puzzle(example, false) },
-		},
-	)
-	input := cl.NewInput("puzzle.in")
-	cl.Puzzle(
-		cl.Ex[int]{
-			Want: 956,
-			Fn:   func() int { return puzzle(input, false) },
-		},
-		cl.Ex[int]{
-			Want: 40149,
-			Fn:   func() int { return puzzle(input, true) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2015, 7, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 65079).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Puzzle()
 }
 
 const (
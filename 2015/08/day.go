@@ -1,4 +1,4 @@
-package main
+package day08
 
 import (
 	"github.com/lindeneg/aoc/cl"
@@ -10,29 +10,14 @@ const (
 	X         = 120
 )
 
-func main() {
-	example := cl.NewInput("example.in")
-	cl.Example(
-		cl.Ex[int]{
-			Want: 12,
-			Fn:   func() int { return puzzle(example, false) },
-		},
-		cl.Ex[int]{
-			Want: 19,
-			Fn:   func() int { return puzzle(example, true) },
-		},
-	)
-	input := cl.NewInput("puzzle.in")
-	cl.Puzzle(
-		cl.Ex[int]{
-			Want: 1371,
-			Fn:   func() int { return puzzle(input, false) },
-		},
-		cl.Ex[int]{
-			Want: 2117,
-			Fn:   func() int { return puzzle(input, true) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2015, 8, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 12).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 19).
+		Puzzle()
 }
 
 func puzzle(input cl.Input, part2 bool) int {
@@ -0,0 +1,49 @@
+package day09
+
+import (
+	"github.com/lindeneg/aoc/cl"
+	"github.com/lindeneg/aoc/cl/comb"
+)
+
+func init() {
+	d := cl.NewDay(2015, 9, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 605).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 982).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	dist := make(map[[2]string]int)
+	cities := []string{}
+	seen := cl.NewSet[string]()
+	for _, line := range input.R1 {
+		r := cl.MustParse[route](line, "{from} to {to} = {dist}")
+		dist[[2]string{r.From, r.To}] = r.Dist
+		dist[[2]string{r.To, r.From}] = r.Dist
+		for _, c := range []string{r.From, r.To} {
+			if !seen.Has(c) {
+				seen.Add(c)
+				cities = append(cities, c)
+			}
+		}
+	}
+	best := 0
+	for p := range comb.Permutations(cities) {
+		current := 0
+		for i := 1; i < len(p); i++ {
+			current += dist[[2]string{p[i-1], p[i]}]
+		}
+		if best == 0 || ((part2 && current > best) || (!part2 && current < best)) {
+			best = current
+		}
+	}
+	return best
+}
+
+type route struct {
+	From, To string
+	Dist     int
+}
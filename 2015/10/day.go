@@ -0,0 +1,43 @@
+package day10
+
+import (
+	"github.com/lindeneg/aoc/cl"
+)
+
+func init() {
+	d := cl.NewDay(2015, 10, cl.Chars)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 82350).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 1166642).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	rounds := 40
+	if part2 {
+		rounds = 50
+	}
+	r := input.B
+	for i := 0; i < rounds; i++ {
+		r = solve(r)
+	}
+	return len(r)
+}
+
+func solve(B []byte) []byte {
+	b := []byte{}
+	i := 0
+	for i < len(B) {
+		cur := B[i]
+		j := i + 1
+		for j < len(B) && B[j] == cur {
+			j++
+		}
+		diff := j - i
+		b = append(b, byte(diff+48), cur)
+		i += diff
+	}
+	return b
+}
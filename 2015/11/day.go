@@ -1,33 +1,17 @@
-package main
+package day11
 
 import (
 	"github.com/lindeneg/aoc/cl"
 )
 
-func main() {
-	example1 := cl.NewInputS("example1.in")
-	example2 := cl.NewInputS("example2.in")
-	cl.Example(
-		cl.Ex[string]{
-			Want: "abcdffaa",
-			Fn:   func() string { return puzzle(example1, false) },
-		},
-		cl.Ex[string]{
-			Want: "ghjaabcc",
-			Fn:   func() string { return puzzle(example2, false) },
-		},
-	)
-	input := cl.NewInputS("puzzle.in")
-	cl.Puzzle(
-		cl.Ex[string]{
-			Want: "vzbxxyzz",
-			Fn:   func() string { return puzzle(input, false) },
-		},
-		cl.Ex[string]{
-			Want: "vzcaabcc",
-			Fn:   func() string { return puzzle(input, true) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2015, 11, cl.Chars)
+	cl.Solve(d, 1, func(input cl.Input) string { return puzzle(input, false) }).
+		Example("example1.in", "abcdffaa").
+		Example("example2.in", "ghjaabcc").
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) string { return puzzle(input, true) }).
+		Puzzle()
 }
 
 func puzzle(input cl.Input, part2 bool) string {
@@ -1,4 +1,4 @@
-package main
+package day12
 
 import (
 	"encoding/json"
@@ -6,29 +6,14 @@ import (
 	"github.com/lindeneg/aoc/cl"
 )
 
-func main() {
-	example := cl.ReadFile("example.in")
-	cl.Example(
-		cl.Ex[int]{
-			Want: 38,
-			Fn:   func() int { return puzzle(example, false) },
-		},
-		cl.Ex[int]{
-			Want: 13,
-			Fn:   func() int { return puzzle(example, true) },
-		},
-	)
-	input := cl.ReadFile("puzzle.in")
-	cl.Puzzle(
-		cl.Ex[int]{
-			Want: 111754,
-			Fn:   func() int { return puzzle(input, false) },
-		},
-		cl.Ex[int]{
-			Want: 65402,
-			Fn:   func() int { return puzzle(input, true) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2015, 12, cl.Chars)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input.B, false) }).
+		Example("example.in", 38).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input.B, true) }).
+		Example("example.in", 13).
+		Puzzle()
 }
 
 func puzzle(b []byte, part2 bool) int {
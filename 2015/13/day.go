@@ -0,0 +1,72 @@
+package day13
+
+import (
+	"github.com/lindeneg/aoc/cl"
+	"github.com/lindeneg/aoc/cl/comb"
+)
+
+func init() {
+	d := cl.NewDay(2015, 13, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 330).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 286).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	g := makeParticipants(input.R1, part2)
+	names := make([]string, 0, len(g))
+	for name := range g {
+		names = append(names, name)
+	}
+	// The table is round, so fixing the first seat loses nothing.
+	first, rest := names[0], names[1:]
+	totalBest := 0
+	for seats := range comb.Permutations(rest) {
+		happiness := 0
+		prev := first
+		for _, name := range seats {
+			happiness += g[prev][name] + g[name][prev]
+			prev = name
+		}
+		happiness += g[prev][first] + g[first][prev]
+		if totalBest == 0 || happiness > totalBest {
+			totalBest = happiness
+		}
+	}
+	return totalBest
+}
+
+type seating struct {
+	From, To string
+	Change   string
+	Units    int
+}
+
+type Participants map[string]map[string]int
+
+func makeParticipants(R1 []string, part2 bool) Participants {
+	miles := "Miles"
+	g := make(Participants)
+	if part2 {
+		g[miles] = make(map[string]int)
+	}
+	for _, line := range R1 {
+		s := cl.MustParse[seating](line, "{from} would {change} {units} happiness units by sitting next to {to}.")
+		from, to, weight := s.From, s.To, s.Units
+		if s.Change == "lose" {
+			weight = -weight
+		}
+		if _, ok := g[from]; !ok {
+			g[from] = make(map[string]int)
+		}
+		g[from][to] = weight
+		if part2 {
+			g[from][miles] = 0
+			g[miles][from] = 0
+		}
+	}
+	return g
+}
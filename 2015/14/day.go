@@ -0,0 +1,30 @@
+package day14
+
+import "github.com/lindeneg/aoc/cl"
+
+func init() {
+	d := cl.NewDay(2015, 14, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, 1000, false) })
+	//		Example("example.in", 1120)
+	//	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, 2503, false) }).
+	//		Puzzle()
+	//	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, 1000, true) }).
+	//		Example("example.in", 42)
+	//	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, 2503, true) }).
+	//		Puzzle()
+}
+
+func puzzle(input cl.Input, seconds int, _ bool) int {
+	return 0
+}
+
+type reindeer struct {
+	Name             string
+	Speed, Fly, Rest int
+}
+
+func newReindeer(s string) *reindeer {
+	r := cl.MustParse[reindeer](s,
+		"{name} can fly {speed} km/s for {fly} seconds, but then must rest for {rest} seconds.")
+	return &r
+}
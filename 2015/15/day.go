@@ -0,0 +1,17 @@
+package day15
+
+import "github.com/lindeneg/aoc/cl"
+
+func init() {
+	d := cl.NewDay(2015, 15, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) })
+	//		Example("example.in", 62842880).
+	//		Puzzle()
+	//	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+	//		Example("example.in", 57600000).
+	//		Puzzle()
+}
+
+func puzzle(input cl.Input, _ bool) int {
+	return 0
+}
@@ -0,0 +1,66 @@
+package day01
+
+import (
+	"slices"
+
+	"github.com/lindeneg/aoc/cl"
+	"github.com/lindeneg/aoc/cl/it"
+)
+
+func init() {
+	d := cl.NewDay(2024, 1, cl.Lines)
+	cl.SolveCtx(d, 1, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, false) }).
+		Example("example.in", 11).
+		Puzzle()
+	cl.SolveCtx(d, 2, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, true) }).
+		Example("example.in", 31).
+		Puzzle()
+}
+
+func puzzle(ctx *cl.Ctx, input cl.Input, part2 bool) int {
+	left, right := LeftRightInts(input.Ints())
+	if part2 {
+		return puzzle2(ctx, left, right)
+	}
+	return puzzle1(left, right)
+}
+
+func puzzle1(left []int, right []int) int {
+	slices.Sort(left)
+	slices.Sort(right)
+	ans := 0
+	for l, r := range it.Zip(slices.Values(left), slices.Values(right)) {
+		ans += cl.AbsInt(l - r)
+	}
+	return ans
+}
+
+func puzzle2(ctx *cl.Ctx, left []int, right []int) int {
+	occurances := cl.NewMemo[int, int](ctx, 0).Func(func(_ func(int) int, n int) int {
+		count := 0
+		for _, r := range right {
+			if n == r {
+				count++
+			}
+		}
+		return n * count
+	})
+	ans := 0
+	for _, l := range left {
+		ans += occurances(l)
+	}
+	return ans
+}
+
+func LeftRightInts(lines [][]int) ([]int, []int) {
+	left := make([]int, 0, len(lines))
+	right := make([]int, 0, len(lines))
+	for _, line := range lines {
+		if len(line) < 2 {
+			break
+		}
+		left = append(left, line[0])
+		right = append(right, line[len(line)-1])
+	}
+	return left, right
+}
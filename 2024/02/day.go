@@ -1,40 +1,21 @@
-package main
+package day02
 
-import (
-	"strings"
+import "github.com/lindeneg/aoc/cl"
 
-	"github.com/lindeneg/aoc/cl"
-)
-
-func main() {
-	example := cl.NewInput("example.in")
-	cl.Example(
-		cl.Ex[int]{
-			Want: 2,
-			Fn:   func() int { return puzzle(example, false) },
-		},
-		cl.Ex[int]{
-			Want: 4,
-			Fn:   func() int { return puzzle(example, true) },
-		},
-	)
-	input := cl.NewInput("puzzle.in")
-	cl.Puzzle(
-		cl.Ex[int]{
-			Want: 242,
-			Fn:   func() int { return puzzle(input, false) },
-		},
-		cl.Ex[int]{
-			Want: 311,
-			Fn:   func() int { return puzzle(input, true) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2024, 2, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 2).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 4).
+		Puzzle()
 }
 
 func puzzle(input cl.Input, part2 bool) int {
 	safe := 0
-	for _, l := range input.R1 {
-		r := line(l).parseLine()
+	for _, l := range input.Ints() {
+		r := iline(l)
 		if part2 {
 			safe += r.safeInt2()
 		} else {
@@ -50,18 +31,8 @@ const (
 	DECREASING
 )
 
-type line string
 type iline []int
 
-func (l line) parseLine() iline {
-	is := make([]int, 0)
-	ll := strings.Split(string(l), " ")
-	for _, s := range ll {
-		is = append(is, cl.Number(s))
-	}
-	return is
-}
-
 func (l iline) removeEl(idx int) iline {
 	newSlice := make(iline, 0)
 	for i, ll := range l {
@@ -1,33 +1,17 @@
-package main
+package day03
 
 import (
 	"github.com/lindeneg/aoc/cl"
 )
 
-func main() {
-	example1 := cl.NewInputS("example1.in")
-	example2 := cl.NewInputS("example2.in")
-	cl.Example(
-		cl.Ex[int]{
-			Want: 161,
-			Fn:   func() int { return puzzle(example1, false) },
-		},
-		cl.Ex[int]{
-			Want: 48,
-			Fn:   func() int { return puzzle(example2, true) },
-		},
-	)
-	input := cl.NewInputS("puzzle.in")
-	cl.Puzzle(
-		cl.Ex[int]{
-			Want: 188116424,
-			Fn:   func() int { return puzzle(input, false) },
-		},
-		cl.Ex[int]{
-			Want: 104245808,
-			Fn:   func() int { return puzzle(input, true) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2024, 3, cl.Chars)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example1.in", 161).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example2.in", 48).
+		Puzzle()
 }
 
 func puzzle(in cl.Input, part2 bool) int {
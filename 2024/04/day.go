@@ -0,0 +1,83 @@
+package day04
+
+import (
+	"github.com/lindeneg/aoc/cl"
+)
+
+const (
+	target1 = "XMAS"
+	target2 = "MAS"
+	target3 = "SAM"
+)
+
+var directions = [8]cl.Vec2{
+	cl.V2(1, 1),
+	cl.V2(-1, -1),
+	cl.V2(1, -1),
+	cl.V2(-1, 1),
+	cl.V2(1, 0),
+	cl.V2(-1, 0),
+	cl.V2(0, 1),
+	cl.V2(0, -1),
+}
+
+func init() {
+	d := cl.NewDay(2024, 4, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 18).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 9).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	g := cl.ByteGrid(input)
+	ans := 0
+	for vec := range g.All() {
+		if part2 {
+			ans += puzzle2(g, vec)
+		} else {
+			ans += puzzle1(g, vec)
+		}
+	}
+	return ans
+}
+
+func puzzle1(g cl.Grid[byte], vec cl.Vec2) int {
+	checkDirection := func(v cl.Vec2) int {
+		i := 0
+		for _, c := range g.Line(vec, v) {
+			if i == len(target1) || c != target1[i] {
+				break
+			}
+			i++
+		}
+		if i < len(target1) {
+			return 0
+		}
+		return 1
+	}
+	ans := 0
+	for _, dir := range directions {
+		ans += checkDirection(dir)
+	}
+	return ans
+}
+
+func puzzle2(g cl.Grid[byte], vec cl.Vec2) int {
+	if g.At(vec) != 'A' {
+		return 0
+	}
+	for _, dir := range directions[:4] {
+		a, ok1 := g.Get(vec.Add(dir))
+		s, ok2 := g.Get(vec.Sub(dir))
+		if !ok1 || !ok2 {
+			return 0
+		}
+		if w := string([]byte{a, 'A', s}); w != target2 && w != target3 {
+			return 0
+		}
+	}
+	return 1
+}
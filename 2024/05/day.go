@@ -1,45 +1,29 @@
-package main
+package day05
 
 import (
 	"math"
 	"sort"
-	"strings"
 
 	"github.com/lindeneg/aoc/cl"
 )
 
 type R map[int][]int
 
-func main() {
-	example := cl.NewInputD("example.in")
-	cl.Example(
-		cl.Ex[int]{
-			Want: 143,
-			Fn:   func() int { return puzzle(example, false) },
-		},
-		cl.Ex[int]{
-			Want: 123,
-			Fn:   func() int { return puzzle(example, true) },
-		},
-	)
-	input := cl.NewInputD("puzzle.in")
-	cl.Puzzle(
-		cl.Ex[int]{
-			Want: 5391,
-			Fn:   func() int { return puzzle(input, false) },
-		},
-		cl.Ex[int]{
-			Want: 6142,
-			Fn:   func() int { return puzzle(input, true) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2024, 5, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 143).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 123).
+		Puzzle()
 }
 
 func puzzle(input cl.Input, part2 bool) int {
-	rules := initRules(strings.Split(input.R1[0], "\n"))
-	updates := initUpdates(strings.Split(input.R1[1], "\n"))
+	sections := input.Sections()
+	rules := initRules(sections[0].Ints())
 	ans := 0
-	for _, v := range updates {
+	for _, v := range sections[1].Ints() {
 		ans += solve(rules, v, part2)
 	}
 	return ans
@@ -98,11 +82,10 @@ func brokenRules(rules R, v int, i int, update []int) bool {
 	return false
 }
 
-func initRules(ordering []string) R {
+func initRules(ordering [][]int) R {
 	rules := make(R, 0)
 	for _, v := range ordering {
-		splitted := strings.Split(v, "|")
-		key, rule := cl.Number(splitted[0]), cl.Number(splitted[1])
+		key, rule := v[0], v[1]
 		if _, ok := rules[rule]; !ok {
 			rules[rule] = []int{key}
 		} else {
@@ -111,16 +94,3 @@ func initRules(ordering []string) R {
 	}
 	return rules
 }
-
-func initUpdates(updates []string) [][]int {
-	ns := make([][]int, 0)
-	for _, v := range updates {
-		nn := make([]int, 0)
-		splitted := strings.Split(v, ",")
-		for _, v := range splitted {
-			nn = append(nn, cl.Number(v))
-		}
-		ns = append(ns, nn)
-	}
-	return ns
-}
@@ -0,0 +1,111 @@
+package day06
+
+import (
+	"github.com/lindeneg/aoc/cl"
+)
+
+const (
+	Obstacle = '#'
+	Free     = '.'
+)
+
+// 2nd part takes a few seconds.
+// TODO: try to actually use your brain
+
+func init() {
+	d := cl.NewDay(2024, 6, cl.Lines)
+	cl.SolveCtx(d, 1, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, false) }).
+		Example("example.in", 41).
+		Puzzle()
+	cl.SolveCtx(d, 2, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, true) }).
+		Example("example.in", 6).
+		Puzzle()
+}
+
+func puzzle(ctx *cl.Ctx, input cl.Input, part2 bool) int {
+	g := newGuard(input)
+	if part2 {
+		return g.part2(ctx)
+	}
+	for g.forward() {
+		if ctx.Err() != nil {
+			return -1
+		}
+	}
+	return len(g.uniques)
+}
+
+type guard struct {
+	data     cl.Grid[byte]
+	startPos cl.Vec3
+	pos      cl.Vec3
+	uniques  map[cl.Vec2]bool
+}
+
+func newGuard(input cl.Input) *guard {
+	data := cl.ByteGrid(input)
+	p, ok := data.Find(func(b byte) bool { return b != Free && b != Obstacle })
+	cl.AssertM(ok, "no guard found")
+	v := p.Vec3(int(cl.DirOf(data.At(p))))
+	return &guard{
+		pos:      v,
+		startPos: v,
+		data:     data,
+		uniques:  make(map[cl.Vec2]bool),
+	}
+}
+
+func (g *guard) forward() bool {
+	newPos := cl.Vec3{X: g.pos.X, Y: g.pos.Y, Z: g.pos.Z}
+	move := cl.Dir(g.pos.Z).Vec()
+	newPos.X += move.X
+	newPos.Y += move.Y
+	c, ok := g.data.Get(newPos.Vec2())
+	if !ok {
+		return false
+	}
+	if c == Obstacle {
+		g.pos.Z = int(cl.Dir(g.pos.Z).TurnRight())
+		return g.forward()
+	}
+	g.pos = newPos
+	if _, ok := g.uniques[newPos.Vec2()]; !ok {
+		g.uniques[newPos.Vec2()] = true
+	}
+	return true
+}
+
+func (g *guard) part2(ctx *cl.Ctx) int {
+	matches := 0
+	for i, c := range g.data.Cells {
+		if c != Free {
+			continue
+		}
+		seen := make(map[cl.Vec3]bool)
+		g.data.Cells[i] = Obstacle
+		if g.simulate(ctx, seen) {
+			matches++
+		}
+		g.data.Cells[i] = c
+		g.pos = g.startPos.Copy()
+		if ctx.Err() != nil {
+			return -1
+		}
+	}
+	return matches
+}
+
+// simulate walks the guard until it leaves the grid or loops, giving
+// up when ctx is done.
+func (g *guard) simulate(ctx *cl.Ctx, seen map[cl.Vec3]bool) bool {
+	for steps := 1; g.forward(); steps++ {
+		if steps%(1<<12) == 0 && ctx.Err() != nil {
+			return false
+		}
+		if _, ok := seen[g.pos]; ok {
+			return true
+		}
+		seen[g.pos] = true
+	}
+	return false
+}
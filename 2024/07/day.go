@@ -0,0 +1,79 @@
+package day07
+
+import (
+	"github.com/lindeneg/aoc/cl"
+	"github.com/lindeneg/aoc/cl/comb"
+)
+
+var (
+	P1Operators = []string{"+", "*"}
+	P2Operators = []string{"+", "*", "||"}
+)
+
+func init() {
+	d := cl.NewDay(2024, 7, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 3749).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 11387).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	var operators []string
+	if part2 {
+		operators = P2Operators
+	} else {
+		operators = P1Operators
+	}
+	ans := 0
+	for _, v := range input.Ints() {
+		expected, operands := v[0], v[1:]
+		ans += solve(expected, operands, operators)
+	}
+	return ans
+}
+
+func solve(want int, operands []int, operators []string) int {
+	for v := range comb.ProductN(operators, len(operands)-1) {
+		if calculate(operands, v) == want {
+			return want
+		}
+	}
+	return 0
+}
+
+func calculate(operands []int, operators []string) int {
+	ans := operands[0]
+	for i, v := range operators {
+		switch v {
+		case "+":
+			ans += operands[i+1]
+		case "*":
+			ans *= operands[i+1]
+		case "||":
+			ans = combineNumbers(ans, operands[i+1])
+		}
+	}
+	return ans
+}
+
+func combineNumbers(a, b int) int {
+	multiplier := 1
+	for b >= multiplier {
+		multiplier *= 10
+	}
+	return a*multiplier + b
+}
+
+func endsWith(a, b int) bool {
+	for b > 0 {
+		if a%10 != b%10 {
+			return false
+		}
+		a /= 10
+		b /= 10
+	}
+	return true
+}
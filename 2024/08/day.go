@@ -0,0 +1,74 @@
+package day08
+
+import (
+	"github.com/lindeneg/aoc/cl"
+)
+
+type A = cl.Set[cl.Vec2]
+
+func init() {
+	d := cl.NewDay(2024, 8, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 14).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 34).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	antinodes := cl.NewSet[cl.Vec2]()
+	g := cl.ByteGrid(input)
+	for pos, c := range g.All() {
+		if c != '.' {
+			solve(g, pos, c, antinodes, part2)
+		}
+	}
+	return antinodes.Len()
+}
+
+func solve(g cl.Grid[byte], pos cl.Vec2, antenna byte, antinodes A, part2 bool) {
+	for other, c := range g.All() {
+		if other == pos || c != antenna {
+			continue
+		}
+		if part2 {
+			findAntinodesP2(g, pos, other, antinodes)
+		} else {
+			findAntinodesP1(g, pos, other, antinodes)
+		}
+	}
+}
+
+func findAntinodesP1(g cl.Grid[byte], origin cl.Vec2, target cl.Vec2, antinodes A) {
+	ot := target.Sub(origin)
+	originAnode := origin.Add(ot.Scale(-1))
+	if g.In(originAnode) {
+		antinodes.Add(originAnode)
+	}
+	targetAnode := target.Add(ot)
+	if g.In(targetAnode) {
+		antinodes.Add(targetAnode)
+	}
+}
+
+func findAntinodesP2(g cl.Grid[byte], origin cl.Vec2, target cl.Vec2, antinodes A) {
+	ab := target.Sub(origin)
+	extendLine(g, origin, ab, antinodes, 1)
+	extendLine(g, origin, ab, antinodes, -1)
+	extendLine(g, target, ab, antinodes, 1)
+	extendLine(g, target, ab, antinodes, -1)
+}
+
+func extendLine(
+	g cl.Grid[byte],
+	start cl.Vec2,
+	direction cl.Vec2,
+	antinodes A,
+	step int,
+) {
+	d := direction.Scale(step)
+	for newPos := range g.Line(start.Add(d), d) {
+		antinodes.Add(newPos)
+	}
+}
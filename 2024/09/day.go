@@ -1,4 +1,4 @@
-package main
+package day09
 
 import (
 	"github.com/lindeneg/aoc/cl"
@@ -8,29 +8,14 @@ const (
 	Free = -1
 )
 
-func main() {
-	example := cl.NewInputS("example.in")
-	cl.Example(
-		cl.Ex[int]{
-			Want: 1928,
-			Fn:   func() int { return puzzle(example, false) },
-		},
-		cl.Ex[int]{
-			Want: 2858,
-			Fn:   func() int { return puzzle(example, true) },
-		},
-	)
-	input := cl.NewInputS("puzzle.in")
-	cl.Puzzle(
-		cl.Ex[int]{
-			Want: 6258319840548,
-			Fn:   func() int { return puzzle(input, false) },
-		},
-		cl.Ex[int]{
-			Want: 6286182965311,
-			Fn:   func() int { return puzzle(input, true) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2024, 9, cl.Chars)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 1928).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 2858).
+		Puzzle()
 }
 
 func puzzle(input cl.Input, part2 bool) int {
@@ -0,0 +1,65 @@
+package day10
+
+import (
+	"github.com/lindeneg/aoc/cl"
+)
+
+const (
+	Trailhead = 0
+	Trailtail = 9
+)
+
+const (
+	Unexplored int = iota
+	Explored
+)
+
+func init() {
+	d := cl.NewDay(2024, 10, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 36).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 81).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	var countMap = map[cl.Vec2]int{}
+	g := cl.DigitGrid(input)
+	for pos, h := range g.All() {
+		if h == Trailtail {
+			solve(g, pos, countMap, part2)
+		}
+	}
+	ans := 0
+	for _, v := range countMap {
+		ans += v
+	}
+	return ans
+}
+
+func solve(g cl.Grid[int], pos cl.Vec2, countMap map[cl.Vec2]int, part2 bool) {
+	q := cl.NewQueue[cl.Vec2]()
+	explored := map[cl.Vec2]bool{}
+	q.Push(pos)
+	for {
+		v, ok := q.Pop()
+		if !ok {
+			break
+		}
+		if g.At(v) == Trailhead {
+			countMap[v]++
+			continue
+		}
+		for nv := range g.Neighbors4(v) {
+			if !part2 && explored[nv] {
+				continue
+			}
+			if g.At(nv) == g.At(v)-1 {
+				explored[nv] = true
+				q.Push(nv)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package day11
+
+import (
+	"math"
+
+	"github.com/lindeneg/aoc/cl"
+)
+
+func init() {
+	d := cl.NewDay(2024, 11, cl.Words)
+	cl.SolveCtx(d, 1, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, false) }).
+		Example("example.in", 55312).
+		Puzzle()
+	cl.SolveCtx(d, 2, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, true) }).
+		Example("example.in", 65601038650482).
+		Puzzle()
+}
+
+func puzzle(ctx *cl.Ctx, input cl.Input, part2 bool) int {
+	var blinks int
+	if part2 {
+		blinks = 75
+	} else {
+		blinks = 25
+	}
+	stones := makeStones(input.R1)
+	count := cl.NewMemo[blink, int](ctx, 0).Func(countStones)
+	ans := 0
+	for _, v := range stones {
+		ans += count(blink{v, blinks})
+	}
+	return ans
+}
+
+type blink struct {
+	stone, round int
+}
+
+// countStones returns how many stones b.stone turns into after
+// b.round blinks.
+func countStones(count func(blink) int, b blink) int {
+	if b.round == 0 {
+		return 1
+	}
+	ans := 0
+	for _, v := range eval(b.stone) {
+		ans += count(blink{v, b.round - 1})
+	}
+	return ans
+}
+
+func eval(n int) []int {
+	if n == 0 {
+		return []int{1}
+	}
+	if n1, n2, ok := splitNumber(n); ok {
+		return []int{n1, n2}
+	}
+	return []int{n * 2024}
+}
+
+func makeStones(R1 []string) []int {
+	stones := make([]int, len(R1))
+	for i, v := range R1 {
+		stones[i] = cl.Number(v)
+	}
+	return stones
+}
+
+func splitNumber(n int) (int, int, bool) {
+	digitCount := cl.DigitCount(n)
+	if digitCount%2 != 0 {
+		return 0, 0, false
+	}
+	halfDigits := digitCount / 2
+	divisor := int(math.Pow(10, float64(halfDigits)))
+	n1 := n / divisor
+	n2 := n % divisor
+	return n1, n2, true
+}
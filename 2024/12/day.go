@@ -0,0 +1,130 @@
+package day12
+
+import (
+	"github.com/lindeneg/aoc/cl"
+)
+
+func init() {
+	d := cl.NewDay(2024, 12, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example1.in", 1930).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example2.in", 80).
+		Example("example3.in", 436).
+		Example("example4.in", 236).
+		Example("example5.in", 368).
+		Example("example1.in", 1206).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	g := cl.ByteGrid(input)
+	visited := cl.NewGridSet(g.W, g.H)
+	ans := 0
+	for p, t := range g.All() {
+		if visited.Has(p) {
+			continue
+		}
+		peri, plots := traverse(g, visited, p, t, 0, []cl.Vec2{})
+		if part2 {
+			ans += sides(plots) * len(plots)
+		} else {
+			ans += (peri * len(plots))
+		}
+	}
+	return ans
+}
+
+func traverse(g cl.Grid[byte], visited *cl.GridSet, pos cl.Vec2, t byte, peri int, plots []cl.Vec2) (int, []cl.Vec2) {
+	if visited.Has(pos) {
+		return peri, plots
+	}
+	if g.At(pos) == t {
+		plots = append(plots, pos)
+		peri += countPerimeter(g, pos, t)
+		visited.Add(pos)
+		for np := range g.Neighbors4(pos) {
+			peri, plots = traverse(g, visited, np, t, peri, plots)
+		}
+	}
+	return peri, plots
+}
+
+// countPerimeter counts the sides of pos that face another plant or
+// the edge of the map.
+func countPerimeter(g cl.Grid[byte], pos cl.Vec2, t byte) int {
+	peri := 4
+	for np := range g.Neighbors4(pos) {
+		if g.At(np) == t {
+			peri--
+		}
+	}
+	return peri
+}
+
+func sides(plots []cl.Vec2) int {
+	valid := map[cl.Vec2]bool{}
+	validIdx := func(v cl.Vec2) bool {
+		if v, ok := valid[v]; ok {
+			return v
+		}
+		ans := false
+		for _, p := range plots {
+			if p.Equals(v) {
+				ans = true
+				break
+			}
+		}
+		valid[v] = ans
+		return ans
+	}
+	ans := 0
+	for _, p := range plots {
+		upleft := p.UpLeft()
+		up := p.Up()
+		upright := p.UpRight()
+		right := p.Right()
+		downright := p.DownRight()
+		down := p.Down()
+		downleft := p.DownLeft()
+		left := p.Left()
+		if !validIdx(up) && !validIdx(upright) && !validIdx(right) {
+			ans++
+		}
+		if !validIdx(up) && !validIdx(upleft) && !validIdx(left) {
+			ans++
+		}
+		if !validIdx(down) && !validIdx(downleft) && !validIdx(left) {
+			ans++
+		}
+		if !validIdx(down) && !validIdx(downright) && !validIdx(right) {
+			ans++
+		}
+		if validIdx(up) && validIdx(right) && !validIdx(upright) {
+			ans++
+		}
+		if validIdx(up) && validIdx(left) && !validIdx(upleft) {
+			ans++
+		}
+		if validIdx(down) && validIdx(left) && !validIdx(downleft) {
+			ans++
+		}
+		if validIdx(down) && validIdx(right) && !validIdx(downright) {
+			ans++
+		}
+		if !validIdx(up) && !validIdx(left) && validIdx(upleft) {
+			ans++
+		}
+		if !validIdx(up) && !validIdx(right) && validIdx(upright) {
+			ans++
+		}
+		if !validIdx(down) && !validIdx(left) && validIdx(downleft) {
+			ans++
+		}
+		if !validIdx(down) && !validIdx(right) && validIdx(downright) {
+			ans++
+		}
+	}
+	return ans
+}
@@ -0,0 +1,59 @@
+package day13
+
+import (
+	"strings"
+
+	"github.com/lindeneg/aoc/cl"
+)
+
+var (
+	P2Adder = cl.V2(10000000000000, 10000000000000)
+)
+
+func init() {
+	d := cl.NewDay(2024, 13, cl.Sections)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example.in", 480).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example.in", 875318608908).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	ans := 0
+	for _, v := range input.R1 {
+		ans += parseMachine(v, part2)
+	}
+	return ans
+}
+
+type machine struct {
+	A, B, Prize cl.Vec2
+}
+
+const machinePattern = "Button A: X+{a.x}, Y+{a.y}\nButton B: X+{b.x}, Y+{b.y}\nPrize: X={prize.x}, Y={prize.y}"
+
+func parseMachine(v string, part2 bool) int {
+	m := cl.MustParse[machine](strings.TrimSpace(v), machinePattern)
+	a, b, p := m.A, m.B, m.Prize
+	if part2 {
+		return solve(a, b, p.Add(P2Adder))
+	}
+	return solve(a, b, p)
+}
+
+func solve(a, b, p cl.Vec2) int {
+	if (a.X*b.Y)-(b.X*a.Y) == 0 {
+		return 0
+	}
+	eq1 := cl.V3(a.X, b.X, p.X)
+	eq2 := cl.V3(a.Y, b.Y, p.Y)
+	elim := eq1.Scale(b.Y).Sub(eq2.Scale(b.X))
+	A := elim.Z / elim.X
+	B := (eq1.Z - (eq1.X * A)) / eq1.Y
+	if !cl.V2((a.X*A)+(b.X*B), (a.Y*A)+(b.Y*B)).Equals(p) {
+		return 0
+	}
+	return (A * 3) + B
+}
@@ -0,0 +1,141 @@
+package day14
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/lindeneg/aoc/cl"
+	"github.com/lindeneg/aoc/cl/num"
+	"github.com/lindeneg/aoc/cl/viz"
+)
+
+func init() {
+	d := cl.NewDay(2024, 14, cl.Lines)
+	cl.SolveCtx(d, 1, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, false) }).
+		Example("example.in", 12).
+		Puzzle()
+	cl.SolveCtx(d, 2, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, true) }).
+		Puzzle()
+}
+
+func puzzle(ctx *cl.Ctx, input cl.Input, part2 bool) int {
+	size := cl.MustParse[cl.Vec2](input.R1[0], "{x},{y}")
+	halfSize := cl.V2(int(math.Floor(float64(size.X)/2)), int(math.Floor(float64(size.Y)/2)))
+	r := findRobots(input.R1[1:], size)
+	if part2 {
+		t := findTree(r, size)
+		if path := ctx.VizPath(".png"); path != "" {
+			if err := viz.SavePNG(path, picture(r, size, t)); err != nil {
+				ctx.Logf("%v", err)
+			}
+		}
+		return t
+	}
+	rr := r
+	for range 100 {
+		rrr := cl.NewTorus[[]robot](size.X, size.Y)
+		for pos, rs := range rr.All() {
+			for _, r := range rs {
+				newPos := rrr.Add(pos, r.Vel)
+				rrr.Set(newPos, append(rrr.At(newPos), r))
+			}
+		}
+		rr = rrr
+	}
+	return safetyScore(rr, size, halfSize)
+}
+
+// findTree returns the first second at which the robots bunch up.
+// Every X repeats after size.X seconds and every Y after size.Y, so
+// the seconds at which each axis is least spread out are combined
+// with the Chinese remainder theorem.
+func findTree(r R, size cl.Vec2) int {
+	var robots []robot
+	for _, rs := range r.All() {
+		robots = append(robots, rs...)
+	}
+	tx := tightest(size.X, func(r robot) (int, int) { return r.Pos.X, r.Vel.X }, robots)
+	ty := tightest(size.Y, func(r robot) (int, int) { return r.Pos.Y, r.Vel.Y }, robots)
+	t, _, ok := num.CRT([]int{tx, ty}, []int{size.X, size.Y})
+	cl.AssertM(ok, "no second with both axes at %d and %d", tx, ty)
+	return t
+}
+
+// picture draws the robots as they are after t seconds.
+func picture(r R, size cl.Vec2, t int) image.Image {
+	g := cl.NewGrid[byte](size.X, size.Y)
+	for pos, rs := range r.All() {
+		for _, rb := range rs {
+			g.Set(pos.Add(rb.Vel.Scale(t)).Mod(size), '#')
+		}
+	}
+	p := viz.NewPalette(color.Black, map[byte]color.Color{'#': color.RGBA{40, 200, 80, 255}})
+	return p.Image(g, 4)
+}
+
+// tightest returns the second in [0, n) at which one coordinate of
+// the robots has the smallest variance.
+func tightest(n int, axis func(robot) (p, v int), robots []robot) int {
+	best, bestVar := 0, -1
+	for t := range n {
+		sum, sq := 0, 0
+		for _, r := range robots {
+			p, v := axis(r)
+			x := num.Mod(p+v*t, n)
+			sum += x
+			sq += x * x
+		}
+		// The variance times len(robots) squared.
+		variance := len(robots)*sq - sum*sum
+		if bestVar < 0 || variance < bestVar {
+			best, bestVar = t, variance
+		}
+	}
+	return best
+}
+
+func safetyScore(r R, size cl.Vec2, halfSize cl.Vec2) int {
+	q := [4]int{0, 0, 0, 0}
+	for pos, rs := range r.All() {
+		if pos.X >= 0 && pos.X < halfSize.X && pos.Y >= 0 && pos.Y < halfSize.Y {
+			q[0] += len(rs)
+			continue
+		}
+		if pos.X > halfSize.X && pos.X < size.X && pos.Y >= 0 && pos.Y < halfSize.Y {
+			q[1] += len(rs)
+			continue
+		}
+		if pos.X >= 0 && pos.X < halfSize.X && pos.Y > halfSize.Y && pos.Y < size.Y {
+			q[2] += len(rs)
+			continue
+		}
+		if pos.X > halfSize.X && pos.X < size.X && pos.Y > halfSize.Y && pos.Y < size.Y {
+			q[3] += len(rs)
+			continue
+		}
+	}
+	return q[0] * q[1] * q[2] * q[3]
+}
+
+type R = *cl.SparseGrid[[]robot]
+
+type robot struct {
+	Pos cl.Vec2
+	Vel cl.Vec2
+}
+
+func (r robot) String() string {
+	return fmt.Sprintf("p=%v,v=%v", r.Pos, r.Vel)
+}
+
+func findRobots(input []string, size cl.Vec2) R {
+	robots := cl.NewTorus[[]robot](size.X, size.Y)
+	p := cl.MustPattern[robot]("p={pos.x},{pos.y} v={vel.x},{vel.y}")
+	for _, v := range input {
+		r := p.MustParse(v)
+		robots.Set(r.Pos, append(robots.At(r.Pos), r))
+	}
+	return robots
+}
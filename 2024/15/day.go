@@ -0,0 +1,172 @@
+package day15
+
+import (
+	"image/color"
+
+	"github.com/lindeneg/aoc/cl"
+	"github.com/lindeneg/aoc/cl/viz"
+)
+
+func init() {
+	d := cl.NewDay(2024, 15, cl.Lines)
+	cl.SolveCtx(d, 1, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, false) }).
+		Example("example1.in", 2028).
+		Example("example2.in", 10092).
+		Puzzle()
+	cl.SolveCtx(d, 2, func(ctx *cl.Ctx, input cl.Input) int { return puzzle(ctx, input, true) }).
+		Example("example2.in", 9021).
+		Puzzle()
+}
+
+func puzzle(ctx *cl.Ctx, input cl.Input, part2 bool) int {
+	sections := input.Sections()
+	g, r := makeMap(cl.ByteGrid(sections[0]), part2)
+	moves := sections[1].B
+	rec := newRecorder(ctx, len(moves))
+outer:
+	for _, v := range moves {
+		if v == '\n' {
+			continue outer
+		}
+		rec.add(g, r)
+		d := cl.DirOf(v).Vec()
+		np := r.Add(d)
+		switch g.At(np) {
+		case '#':
+			continue outer
+		case '.':
+			r = np
+		case '[', ']', 'O':
+			q := cl.NewQueue[cl.Vec2]()
+			q.Push(r)
+			seen := cl.NewSet[cl.Vec2]()
+			wall := false
+		inner:
+			for {
+				np, ok := q.Pop()
+				if !ok {
+					break
+				}
+				if seen.Has(np) {
+					continue inner
+				}
+				seen.Add(np)
+				nnp := np.Add(d)
+				switch g.At(nnp) {
+				case '#':
+					wall = true
+					break inner
+				case 'O':
+					q.Push(nnp)
+				case '[':
+					q.Push(nnp)
+					right := nnp.Right()
+					cl.AssertE(g.At(right), ']')
+					q.Push(right)
+				case ']':
+					q.Push(nnp)
+					left := nnp.Left()
+					cl.AssertE(g.At(left), '[')
+					q.Push(left)
+				}
+			}
+			if wall {
+				continue outer
+			}
+			for seen.Len() > 0 {
+				keys := seen.Sorted(cl.Vec2Less)
+				for _, k := range keys {
+					nk := k.Add(d)
+					if !seen.Has(nk) {
+						cl.AssertE(g.At(nk), '.')
+						g.Set(nk, g.At(k))
+						g.Set(k, '.')
+						seen.Remove(k)
+					}
+				}
+			}
+			r = r.Add(d)
+		}
+	}
+	rec.add(g, r)
+	rec.save(ctx)
+	return sumPositions(g)
+}
+
+func sumPositions(g cl.Grid[byte]) int {
+	ans := 0
+	for p, v := range g.All() {
+		if v == '[' || v == 'O' {
+			ans += (100 * p.Y) + p.X
+		}
+	}
+	return ans
+}
+
+func makeMap(src cl.Grid[byte], part2 bool) (cl.Grid[byte], cl.Vec2) {
+	if !part2 {
+		g := src.Clone()
+		rp, ok := g.Find(func(c byte) bool { return c == '@' })
+		cl.AssertM(ok, "no robot found")
+		g.Set(rp, '.')
+		return g, rp
+	}
+	g := cl.NewGrid[byte](src.W*2, src.H)
+	var rp cl.Vec2
+	for p, c := range src.All() {
+		l, r := c, c
+		switch c {
+		case 'O':
+			l, r = '[', ']'
+		case '@':
+			rp = cl.V2(p.X*2, p.Y)
+			l, r = '.', '.'
+		}
+		g.Set(cl.V2(p.X*2, p.Y), l)
+		g.Set(cl.V2(p.X*2+1, p.Y), r)
+	}
+	return g, rp
+}
+
+var palette = viz.NewPalette(color.RGBA{15, 15, 35, 255}, map[byte]color.Color{
+	'#': color.RGBA{110, 110, 120, 255},
+	'O': color.RGBA{200, 140, 60, 255},
+	'[': color.RGBA{200, 140, 60, 255},
+	']': color.RGBA{170, 115, 45, 255},
+	'@': color.RGBA{230, 50, 50, 255},
+})
+
+// recorder animates the moves of the robot, it is nil unless the
+// case is to be visualized.
+type recorder struct {
+	*viz.Recorder
+	path string
+}
+
+func newRecorder(ctx *cl.Ctx, moves int) *recorder {
+	path := ctx.VizPath(".gif")
+	if path == "" {
+		return nil
+	}
+	r := &recorder{viz.NewRecorder(palette, 8, 4), path}
+	r.Every = max(moves/400, 1)
+	return r
+}
+
+func (r *recorder) add(g cl.Grid[byte], robot cl.Vec2) {
+	if r == nil {
+		return
+	}
+	g.Set(robot, '@')
+	r.Add(g)
+	g.Set(robot, '.')
+}
+
+func (r *recorder) save(ctx *cl.Ctx) {
+	if r == nil {
+		return
+	}
+	if err := r.SaveGIF(r.path); err != nil {
+		ctx.Logf("%v", err)
+	}
+}
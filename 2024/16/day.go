@@ -0,0 +1,61 @@
+package day16
+
+import (
+	"github.com/lindeneg/aoc/cl"
+)
+
+func init() {
+	d := cl.NewDay(2024, 16, cl.Lines)
+	cl.Solve(d, 1, func(input cl.Input) int { return puzzle(input, false) }).
+		Example("example1.in", 7036).
+		Example("example2.in", 11048).
+		Puzzle()
+	cl.Solve(d, 2, func(input cl.Input) int { return puzzle(input, true) }).
+		Example("example1.in", 45).
+		Example("example2.in", 64).
+		Puzzle()
+}
+
+func puzzle(input cl.Input, part2 bool) int {
+	g := cl.ByteGrid(input)
+	start, ok := g.Find(func(c byte) bool { return c == 'S' })
+	cl.Assert(ok)
+	end, ok := g.Find(func(c byte) bool { return c == 'E' })
+	cl.Assert(ok)
+	res := solveMaze(g, start, end)
+	if part2 {
+		return countTiles(res)
+	}
+	if !res.Found {
+		return -1
+	}
+	return res.Cost
+}
+
+type state struct {
+	pos cl.Vec2
+	d   cl.Dir
+}
+
+func solveMaze(g cl.Grid[byte], start, end cl.Vec2) *cl.Search[state] {
+	next := func(s state) []cl.Edge[state] {
+		edges := []cl.Edge[state]{
+			{To: state{s.pos, s.d.TurnLeft()}, Cost: 1000},
+			{To: state{s.pos, s.d.TurnRight()}, Cost: 1000},
+		}
+		if c, ok := g.Get(s.pos.Move(s.d)); ok && c != '#' {
+			edges = append(edges, cl.Edge[state]{To: state{s.pos.Move(s.d), s.d}, Cost: 1})
+		}
+		return edges
+	}
+	return cl.Dijkstra(state{start, cl.Right}, next, func(s state) bool { return s.pos == end })
+}
+
+// countTiles counts the tiles that are part of any best path.
+func countTiles(res *cl.Search[state]) int {
+	tiles := cl.NewSet[cl.Vec2]()
+	for s := range res.OnPath() {
+		tiles.Add(s.pos)
+	}
+	return tiles.Len()
+}
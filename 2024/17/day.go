@@ -1,4 +1,4 @@
-package main
+package day17
 
 import (
 	"bytes"
@@ -27,42 +27,14 @@ type octstr struct {
 
 func (o *octstr) prepend(dec int) {}
 
-func main() {
-	example1 := cl.NewInputD("example1.in")
-	cl.ExpectRun(
-		"Example",
-		1,
-		cl.Ex[string]{
-			Want: "4,6,3,5,6,3,5,2,1,0",
-			Fn:   func() string { return part1(example1) },
-		},
-	)
-	example2 := cl.NewInputD("example2.in")
-	cl.ExpectRun(
-		"Example",
-		2,
-		cl.Ex[int]{
-			Want: 117440,
-			Fn:   func() int { return part2(example2) },
-		},
-	)
-	input := cl.NewInputD("puzzle.in")
-	cl.ExpectRun(
-		"Puzzle",
-		1,
-		cl.Ex[string]{
-			Want: "7,1,3,7,5,1,0,3,4",
-			Fn:   func() string { return part1(input) },
-		},
-	)
-	cl.ExpectRun(
-		"Puzzle",
-		2,
-		cl.Ex[int]{
-			Want: 190384113204239,
-			Fn:   func() int { return part2(input) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2024, 17, cl.Sections)
+	cl.Solve(d, 1, part1).
+		Example("example1.in", "4,6,3,5,6,3,5,2,1,0").
+		Puzzle()
+	cl.SolveCtx(d, 2, part2).
+		Example("example2.in", 117440).
+		Puzzle()
 }
 
 func part1(input cl.Input) string {
@@ -70,7 +42,7 @@ func part1(input cl.Input) string {
 	return eval(machine, program, false).String()
 }
 
-func part2(input cl.Input) int {
+func part2(ctx *cl.Ctx, input cl.Input) int {
 	machine, program := parseMachine(input.B1)
 	a := 0
 	aInitial := 0
@@ -79,6 +51,9 @@ func part2(input cl.Input) int {
 	digits := 0
 	for {
 		a++
+		if a%(1<<12) == 0 && ctx.Err() != nil {
+			return -1
+		}
 		// aInitial = a*int(math.Pow(8, 0)) + 0          // 036017
 		// aInitial = a*int(math.Pow(8, 5)) + 036017     // 01340
 		// aInitial = a*int(math.Pow(8, 9)) + 0134036017
@@ -102,6 +77,8 @@ func part2(input cl.Input) int {
 				a = 0
 			}
 			best = len(e)
+			ctx.Debugf("%d of %d outputs from A=%d", best, len(program), aInitial)
+			ctx.Progress(best, len(program))
 		}
 	}
 }
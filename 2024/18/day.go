@@ -1,52 +1,21 @@
-package main
+package day18
 
 import (
 	"fmt"
 	"strings"
 
 	"github.com/lindeneg/aoc/cl"
+	"github.com/lindeneg/aoc/cl/term"
 )
 
-var directions = []cl.Vec2{
-	cl.V2(0, 1), cl.V2(1, 0),
-	cl.V2(0, -1), cl.V2(-1, 0),
-}
-
-func main() {
-	example := cl.NewInputD("example.in")
-	cl.ExpectRun(
-		"Example",
-		1,
-		cl.Ex[int]{
-			Want: 22,
-			Fn:   func() int { return part1(example) },
-		},
-	)
-	cl.ExpectRun(
-		"Example",
-		2,
-		cl.Ex[cl.Vec2]{
-			Want: cl.V2(6, 1),
-			Fn:   func() cl.Vec2 { return part2(example) },
-		},
-	)
-	input := cl.NewInputD("puzzle.in")
-	cl.ExpectRun(
-		"Puzzle",
-		1,
-		cl.Ex[int]{
-			Want: 446,
-			Fn:   func() int { return part1(input) },
-		},
-	)
-	cl.ExpectRun(
-		"Puzzle",
-		2,
-		cl.Ex[cl.Vec2]{
-			Want: cl.V2(39, 40),
-			Fn:   func() cl.Vec2 { return part2(input) },
-		},
-	)
+func init() {
+	d := cl.NewDay(2024, 18, cl.Sections)
+	cl.Solve(d, 1, part1).
+		Example("example.in", 22).
+		Puzzle()
+	cl.SolveCtx(d, 2, part2).
+		Example("example.in", cl.V2(6, 1)).
+		Puzzle()
 }
 
 func part1(input cl.Input) int {
@@ -62,10 +31,10 @@ func part1(input cl.Input) int {
 	start := cl.V2(0, 0)
 	end := cl.V2(size-1, size-1)
 
-	return len(cl.Djikstra(g, start, end))
+	return g.path(start, end).Cost
 }
 
-func part2(input cl.Input) cl.Vec2 {
+func part2(ctx *cl.Ctx, input cl.Input) cl.Vec2 {
 	var size int
 	var limit int
 	fmt.Sscanf(input.R1[0], "%d,%d", &size, &limit)
@@ -78,8 +47,20 @@ func part2(input cl.Input) cl.Vec2 {
 	start := cl.V2(0, 0)
 	end := cl.V2(size-1, size-1)
 
+	var r *term.Renderer
+	if ctx.Debugging() {
+		r = term.NewRenderer(ctx.Output(), 30)
+		defer r.Close()
+	}
 	// TODO try and be a bit smarter
-	for len(cl.Djikstra(g, start, end)) > 0 {
+	for {
+		res := g.path(start, end)
+		if r != nil {
+			g.draw(r, res.Path())
+		}
+		if !res.Found || ctx.Err() != nil {
+			break
+		}
 		g.limit++
 		g.FallByte()
 	}
@@ -99,7 +80,7 @@ type grid struct {
 func NewGrid(size int, limit int, lines []string) *grid {
 	g := make(cl.B2, size)
 	for i := range g {
-		g[i] = make([]byte, size)
+		g[i] = []byte(strings.Repeat(".", size))
 	}
 	return &grid{
 		g:         g,
@@ -110,16 +91,17 @@ func NewGrid(size int, limit int, lines []string) *grid {
 	}
 }
 
-func (g *grid) Edges(v cl.Vec2) []cl.Vec2 {
-	return directions
-}
-
-func (g *grid) Obstacle(v cl.Vec2) bool {
-	return g.obstacles[v]
-}
-
-func (g *grid) ValidIdx(v cl.Vec2) bool {
-	return g.g.ValidIdx(v)
+func (g *grid) path(start, end cl.Vec2) *cl.Search[cl.Vec2] {
+	next := func(v cl.Vec2) []cl.Vec2 {
+		var out []cl.Vec2
+		for _, n := range v.Neighbors4() {
+			if g.g.ValidIdx(n) && !g.obstacles[n] {
+				out = append(out, n)
+			}
+		}
+		return out
+	}
+	return cl.BFS(start, next, func(v cl.Vec2) bool { return v == end })
 }
 
 func (g *grid) FallByte() *grid {
@@ -129,8 +111,17 @@ func (g *grid) FallByte() *grid {
 		var v cl.Vec2
 		fmt.Sscanf(g.lines[i], "%d,%d", &v.X, &v.Y)
 		g.obstacles[v] = true
+		g.g.S(v, '#')
 		g.lastObstacle = v
 		i++
 	}
 	return g
 }
+
+func (g *grid) draw(r *term.Renderer, path []cl.Vec2) {
+	c := term.Rows(g.g, func(b byte) rune { return rune(b) }).
+		Class('#', term.Gray).
+		Path(path, 'O', term.Green).
+		Style(g.lastObstacle, term.Red.With(term.Bold))
+	r.Draw(c.View(g.lastObstacle, 80, 40), fmt.Sprintf("%d bytes, last at %v", g.limit, g.lastObstacle))
+}
@@ -0,0 +1,130 @@
+package cl
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// answersDir returns a directory with a puzzle input and, unless
+// answers is empty, an answers file holding it.
+func answersDir(t *testing.T, answers string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, PuzzleFile), []byte("1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if answers != "" {
+		if err := os.WriteFile(filepath.Join(dir, AnswersFile), []byte(answers), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func readAnswers(t *testing.T, dir string) map[string]int {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, AnswersFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]int
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestAnswerModes(t *testing.T) {
+	defer func(m AnswerMode) { Answering = m }(Answering)
+	tests := []struct {
+		name    string
+		mode    AnswerMode
+		answers string
+		// pass, note and skipped of parts 1 and 2, which answer 7 and 9
+		pass    [2]bool
+		note    [2]string
+		skipped [2]bool
+		// stored is the answers file afterwards, nil if there is none.
+		stored map[string]int
+	}{
+		{"read", ReadAnswers, `{"1": 7}`,
+			[2]bool{true, true}, [2]string{"", "no answer"}, [2]bool{false, true}, map[string]int{"1": 7}},
+		{"read mismatch", ReadAnswers, `{"1": 8, "2": 9}`,
+			[2]bool{false, true}, [2]string{}, [2]bool{}, map[string]int{"1": 8, "2": 9}},
+		{"record new", RecordAnswers, "",
+			[2]bool{true, true}, [2]string{"recorded", "recorded"}, [2]bool{}, map[string]int{"1": 7, "2": 9}},
+		{"record keeps existing", RecordAnswers, `{"1": 8}`,
+			[2]bool{false, true}, [2]string{"", "recorded"}, [2]bool{}, map[string]int{"1": 8, "2": 9}},
+		{"verify mismatch", VerifyAnswers, `{"1": 8, "2": 9}`,
+			[2]bool{false, true}, [2]string{}, [2]bool{}, map[string]int{"1": 8, "2": 9}},
+		{"verify missing", VerifyAnswers, `{"1": 7}`,
+			[2]bool{true, false}, [2]string{"", "no answer"}, [2]bool{}, map[string]int{"1": 7}},
+		{"verify none", VerifyAnswers, "",
+			[2]bool{false, false}, [2]string{"no answer", "no answer"}, [2]bool{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Answering = tt.mode
+			dir := answersDir(t, tt.answers)
+			d := &Day{Year: 1, Day: 1, Opts: Lines}
+			Solve(d, 1, func(Input) int { return 7 }).Puzzle()
+			Solve(d, 2, func(Input) int { return 9 }).Puzzle()
+			results := d.Run(&Report{}, dir)
+			if len(results) != 2 {
+				t.Fatalf("got %d results", len(results))
+			}
+			for i, res := range results {
+				if res.Pass != tt.pass[i] || res.Note != tt.note[i] || res.Skipped != tt.skipped[i] || res.Error != "" {
+					t.Errorf("part %d: got %+v, want pass %v, note %q, skipped %v", i+1, res, tt.pass[i], tt.note[i], tt.skipped[i])
+				}
+			}
+			if tt.stored == nil {
+				if _, err := os.Stat(filepath.Join(dir, AnswersFile)); !os.IsNotExist(err) {
+					t.Errorf("answers file written: %v", err)
+				}
+				return
+			}
+			got := readAnswers(t, dir)
+			if len(got) != len(tt.stored) {
+				t.Errorf("stored %v, want %v", got, tt.stored)
+			}
+			for k, v := range tt.stored {
+				if got[k] != v {
+					t.Errorf("stored %v, want %v", got, tt.stored)
+				}
+			}
+		})
+	}
+}
+
+func TestAnswersSet(t *testing.T) {
+	dir := t.TempDir()
+	a, err := LoadAnswers(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Set(1, 42); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Set(1, 43); err == nil {
+		t.Error("Set overwrote an answer")
+	}
+	b, err := LoadAnswers(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw, ok := b.Get(1); !ok || string(raw) != "42" {
+		t.Errorf("reloaded %s, %v, want 42", raw, ok)
+	}
+	if _, ok := b.Get(2); ok {
+		t.Error("part 2 has an answer")
+	}
+	if err := os.WriteFile(filepath.Join(dir, AnswersFile), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAnswers(dir); err == nil {
+		t.Error("loaded a broken answers file")
+	}
+}
@@ -0,0 +1,167 @@
+package cl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"runtime"
+	"slices"
+	"time"
+)
+
+type BenchOptions struct {
+	MinRuns int
+	MaxRuns int
+	Time    time.Duration
+	// Timeout limits the checking run of every case, 0 for no limit.
+	Timeout time.Duration
+}
+
+var DefaultBenchOptions = BenchOptions{MinRuns: 5, MaxRuns: 1000, Time: time.Second}
+
+type BenchResult struct {
+	Year        int           `json:"year,omitempty"`
+	Day         int           `json:"day,omitempty"`
+	Name        string        `json:"name"`
+	Part        int           `json:"part"`
+	File        string        `json:"file,omitempty"`
+	Runs        int           `json:"runs"`
+	Min         time.Duration `json:"min_ns"`
+	Median      time.Duration `json:"median_ns"`
+	P95         time.Duration `json:"p95_ns"`
+	AllocsPerOp uint64        `json:"allocs_per_op"`
+	BytesPerOp  uint64        `json:"bytes_per_op"`
+}
+
+func (b BenchResult) Key() string {
+	return fmt.Sprintf("%d/%02d %s %d %s", b.Year, b.Day, b.Name, b.Part, b.File)
+}
+
+func (b BenchResult) String() string {
+	r := Result{Year: b.Year, Day: b.Day, Name: b.Name, Part: b.Part}
+	return fmt.Sprintf("%s %s\truns=%d\tmin=%s\tmedian=%s\tp95=%s\t%d allocs/op\t%d B/op",
+		r.Suite(), r.Label(), b.Runs, b.Min, b.Median, b.P95, b.AllocsPerOp, b.BytesPerOp)
+}
+
+// withTimeout returns ctx limited to opts.Timeout, if it is set.
+func (opts BenchOptions) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if opts.Timeout > 0 {
+		return context.WithTimeout(ctx, opts.Timeout)
+	}
+	return ctx, func() {}
+}
+
+// Bench checks expected once, which also warms up caches and lazy
+// allocations, and then times it repeatedly. Failing expectations
+// are not timed.
+func Bench[T comparable](name string, i int, expected Ex[T], opts BenchOptions) (BenchResult, Result) {
+	ctx, cancel := opts.withTimeout(context.Background())
+	defer cancel()
+	res := check(ctx, DefaultReport, Result{Name: name, Part: i}, expected)
+	b := BenchResult{Name: name, Part: i}
+	if res.Pass {
+		quiet := quietCtx()
+		measure(&b, func() { expected.run(quiet) }, opts)
+	}
+	return b, res
+}
+
+func measure(b *BenchResult, fn func(), opts BenchOptions) {
+	samples := make([]time.Duration, 0, opts.MinRuns)
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for len(samples) < max(opts.MinRuns, 1) ||
+		(len(samples) < opts.MaxRuns && time.Since(start) < opts.Time) {
+		t := time.Now()
+		fn()
+		samples = append(samples, time.Since(t))
+	}
+	runtime.ReadMemStats(&after)
+	slices.Sort(samples)
+	n := len(samples)
+	b.Runs = n
+	b.Min = samples[0]
+	b.Median = samples[n/2]
+	b.P95 = percentile(samples, 0.95)
+	b.AllocsPerOp = (after.Mallocs - before.Mallocs) / uint64(n)
+	b.BytesPerOp = (after.TotalAlloc - before.TotalAlloc) / uint64(n)
+}
+
+// percentile returns the smallest sample that at least p of the
+// sorted samples are less than or equal to.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(i, 0)]
+}
+
+// Bench benchmarks every case of the day in the same order as Run.
+// Correctness of the warm-up run, which is limited to opts.Timeout,
+// is recorded in r.
+func (d *Day) Bench(r *Report, dir string, opts BenchOptions) []BenchResult {
+	var out []BenchResult
+	f := d.files(dir)
+	for _, name := range []string{ExampleName, PuzzleName} {
+		d.cases(name, func(p *Part, c Case) {
+			ctx, cancel := opts.withTimeout(context.Background())
+			defer cancel()
+			res, fn := d.expect(ctx, r, p, c, f)
+			if !res.Pass {
+				return
+			}
+			b := BenchResult{Year: d.Year, Day: d.Day, Name: name, Part: p.N, File: c.File}
+			quiet := quietCtx()
+			measure(&b, func() { fn(quiet) }, opts)
+			out = append(out, b)
+		})
+	}
+	return out
+}
+
+type Baseline map[string]BenchResult
+
+// LoadBaseline returns an empty baseline if path does not exist.
+func LoadBaseline(path string) (Baseline, error) {
+	b := make(Baseline)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
+
+func (b Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func (b Baseline) Update(results ...BenchResult) {
+	for _, r := range results {
+		b[r.Key()] = r
+	}
+}
+
+// Compare returns the relative change of the median against the
+// baseline, and whether it is slower by more than tolerance.
+func (b Baseline) Compare(r BenchResult, tolerance float64) (delta float64, regressed, ok bool) {
+	old, ok := b[r.Key()]
+	if !ok || old.Median == 0 {
+		return 0, false, false
+	}
+	delta = float64(r.Median-old.Median) / float64(old.Median)
+	return delta, delta > tolerance, true
+}
@@ -0,0 +1,125 @@
+package cl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	ms := func(ns ...int) []time.Duration {
+		out := make([]time.Duration, len(ns))
+		for i, n := range ns {
+			out[i] = time.Duration(n) * time.Millisecond
+		}
+		return out
+	}
+	tests := []struct {
+		samples []time.Duration
+		p       float64
+		want    time.Duration
+	}{
+		{ms(7), 0.95, 7 * time.Millisecond},
+		{ms(1, 2, 3, 4, 5), 0.5, 3 * time.Millisecond},
+		{ms(1, 2, 3, 4, 5), 0.95, 5 * time.Millisecond},
+		{ms(1, 2, 3, 4, 5), 0, 1 * time.Millisecond},
+		{ms(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20), 0.95, 19 * time.Millisecond},
+		{ms(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21), 0.95, 20 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.samples, tt.p); got != tt.want {
+			t.Errorf("percentile of %d samples at %v = %s, want %s", len(tt.samples), tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestMeasureRuns(t *testing.T) {
+	tests := []struct {
+		name string
+		opts BenchOptions
+		runs int
+	}{
+		{"min runs", BenchOptions{MinRuns: 3, MaxRuns: 10}, 3},
+		{"max runs", BenchOptions{MinRuns: 1, MaxRuns: 7, Time: time.Hour}, 7},
+		{"at least one", BenchOptions{}, 1},
+	}
+	for _, tt := range tests {
+		calls := 0
+		var b BenchResult
+		measure(&b, func() { calls++ }, tt.opts)
+		if b.Runs != tt.runs || calls != tt.runs {
+			t.Errorf("%s: %d runs and %d calls, want %d", tt.name, b.Runs, calls, tt.runs)
+		}
+		if b.Min > b.Median || b.Median > b.P95 {
+			t.Errorf("%s: min %s, median %s, p95 %s out of order", tt.name, b.Min, b.Median, b.P95)
+		}
+	}
+}
+
+func TestBaselineCompare(t *testing.T) {
+	old := BenchResult{Year: 2024, Day: 1, Name: PuzzleName, Part: 1, File: PuzzleFile, Median: 100 * time.Millisecond}
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	b := make(Baseline)
+	b.Update(old, BenchResult{Year: 2024, Day: 2, Name: PuzzleName, Part: 1})
+	if err := b.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	b, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(median time.Duration) BenchResult {
+		r := old
+		r.Median = median
+		return r
+	}
+	tests := []struct {
+		name          string
+		r             BenchResult
+		delta         float64
+		regressed, ok bool
+	}{
+		{"faster", at(50 * time.Millisecond), -0.5, false, true},
+		{"same", at(100 * time.Millisecond), 0, false, true},
+		{"within tolerance", at(120 * time.Millisecond), 0.2, false, true},
+		{"regressed", at(150 * time.Millisecond), 0.5, true, true},
+		{"not in baseline", BenchResult{Year: 2024, Day: 3, Name: PuzzleName, Part: 1}, 0, false, false},
+		{"zero baseline", BenchResult{Year: 2024, Day: 2, Name: PuzzleName, Part: 1, Median: time.Second}, 0, false, false},
+	}
+	for _, tt := range tests {
+		delta, regressed, ok := b.Compare(tt.r, 0.2)
+		if d := delta - tt.delta; d > 1e-9 || d < -1e-9 || regressed != tt.regressed || ok != tt.ok {
+			t.Errorf("%s: got %v, %v, %v, want %v, %v, %v", tt.name, delta, regressed, ok, tt.delta, tt.regressed, tt.ok)
+		}
+	}
+	if b, err := LoadBaseline(filepath.Join(t.TempDir(), "missing.json")); err != nil || len(b) != 0 {
+		t.Errorf("missing baseline: %v, %v", b, err)
+	}
+}
+
+// TestDayBenchTimeout checks that a case whose checking run is out of
+// time is reported and not benchmarked.
+func TestDayBenchTimeout(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "example.in"), []byte("1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	block := make(chan struct{})
+	defer close(block)
+	d := &Day{Year: 1, Day: 1, Opts: Lines}
+	Solve(d, 1, func(Input) int {
+		<-block
+		return 1
+	}).Example("example.in", 1)
+	Solve(d, 2, func(Input) int { return 2 }).Example("example.in", 2)
+	r := &Report{}
+	results := d.Bench(r, dir, BenchOptions{MinRuns: 1, MaxRuns: 1, Timeout: 10 * time.Millisecond})
+	if len(results) != 1 || results[0].Part != 2 {
+		t.Errorf("benchmarked %v, want part 2 only", results)
+	}
+	if len(r.Results) != 2 || !strings.HasPrefix(r.Results[0].Error, "timed out") {
+		t.Errorf("reported %+v, want a timeout for part 1", r.Results)
+	}
+}
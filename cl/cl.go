@@ -2,40 +2,41 @@ package cl
 
 import (
 	"bytes"
-	"container/heap"
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"iter"
 	"math"
-	"os"
-	"path/filepath"
-	"sort"
+	"slices"
 	"strconv"
 	"strings"
-	"time"
 )
 
+// Assert and the other assertions panic instead of exiting, so a
+// failed assertion fails only the part that made it and the harness
+// goes on with the rest.
 func Assert(c bool) {
 	AssertM(c, "assertion failed")
 }
 
 func AssertM(c bool, m string, args ...any) {
 	if !c {
-		log.Fatalf(m, args...)
+		panic(fmt.Sprintf(m, args...))
 	}
 }
 
 func AssertE[T comparable](a, b T) {
 	if a != b {
-		log.Fatalf("%v is not equal to %v", a, b)
+		panic(fmt.Sprintf("%v is not equal to %v", a, b))
 	}
 }
 
 func VerifyNotReached() {
-	log.Fatalln("unwanted path reached")
+	panic("unwanted path reached")
 }
 
 type Queue[T any] struct {
-	q []T
+	q Deque[T]
 }
 
 func NewQueue[T any]() *Queue[T] {
@@ -43,99 +44,95 @@ func NewQueue[T any]() *Queue[T] {
 }
 
 func (q *Queue[T]) Push(v T) {
-	q.q = append(q.q, v)
+	q.q.PushBack(v)
 }
 
-func (q *Queue[T]) Pop() T {
-	v := q.q[0]
-	q.q = q.q[1:]
-	return v
+// Pop removes the oldest element, ok is false if q is empty.
+func (q *Queue[T]) Pop() (v T, ok bool) {
+	return q.q.PopFront()
+}
+
+// All yields the queued elements in the order Pop returns them.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return q.q.All()
+}
+
+func (q *Queue[T]) Len() int {
+	return q.q.Len()
 }
 
 func (q *Queue[T]) Empty() bool {
-	return len(q.q) == 0
+	return q.q.Empty()
 }
 
-type Prio[T comparable] struct {
+// Prio is a binary min-heap ordered by less.
+type Prio[T any] struct {
 	q    []T
 	less func(a T, b T) bool
 }
 
-func NewPrio[T comparable](less func(a T, b T) bool) *Prio[T] {
-	p := &Prio[T]{q: make([]T, 0), less: less}
-	heap.Init(p)
-	return p
+func NewPrio[T any](less func(a T, b T) bool) *Prio[T] {
+	return &Prio[T]{less: less}
 }
 
 func (pq *Prio[T]) Add(v T) {
-	heap.Push(pq, v)
+	pq.q = append(pq.q, v)
+	pq.up(len(pq.q) - 1)
 }
 
 func (pq *Prio[T]) Next() T {
-	t := heap.Pop(pq)
-	return t.(T)
-}
-
-func (pq *Prio[T]) Len() int { return len(pq.q) }
-
-func (pq *Prio[T]) Less(i, j int) bool {
-	return pq.less(pq.q[i], pq.q[j])
-}
-
-func (pq *Prio[T]) Empty() bool {
-	return pq.Len() == 0
-}
-
-func (pq *Prio[T]) Swap(i, j int) {
-	pq.q[i], pq.q[j] = pq.q[j], pq.q[i]
-}
-
-func (pq *Prio[T]) Push(x interface{}) {
-	pq.q = append(pq.q, x.(T))
-}
-
-func (pq *Prio[T]) Pop() interface{} {
-	old := pq.q
-	n := len(old)
-	x := old[n-1]
-	pq.q = old[0 : n-1]
-	return x
+	n := len(pq.q) - 1
+	v := pq.q[0]
+	pq.q[0] = pq.q[n]
+	var zero T
+	pq.q[n] = zero
+	pq.q = pq.q[:n]
+	pq.down(0)
+	return v
 }
 
-type Seen[T comparable] map[T]bool
-
-func NewSeen[T comparable]() Seen[T] {
-	return make(Seen[T])
+func (pq *Prio[T]) Peek() T {
+	return pq.q[0]
 }
 
-func (s Seen[T]) Add(k T) {
-	s[k] = true
+// All yields the queued elements in no particular order.
+func (pq *Prio[T]) All() iter.Seq[T] {
+	return slices.Values(pq.q)
 }
 
-func (s Seen[T]) Has(k T) bool {
-	v, ok := s[k]
-	return ok && v
-}
+func (pq *Prio[T]) Len() int { return len(pq.q) }
 
-func (s Seen[T]) Remove(k T) {
-	delete(s, k)
+func (pq *Prio[T]) Empty() bool {
+	return pq.Len() == 0
 }
 
-func (s Seen[T]) Len() int {
-	return len(s)
+func (pq *Prio[T]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !pq.less(pq.q[i], pq.q[p]) {
+			return
+		}
+		pq.q[i], pq.q[p] = pq.q[p], pq.q[i]
+		i = p
+	}
 }
 
-func (s Seen[T]) Sorted(less func(a T, b T) bool) []T {
-	keys := make([]T, 0)
-	for k, v := range s {
-		if v {
-			keys = append(keys, k)
+func (pq *Prio[T]) down(i int) {
+	n := len(pq.q)
+	for {
+		m := i
+		if l := 2*i + 1; l < n && pq.less(pq.q[l], pq.q[m]) {
+			m = l
+		}
+		if r := 2*i + 2; r < n && pq.less(pq.q[r], pq.q[m]) {
+			m = r
+		}
+		if m == i {
+			return
 		}
+		pq.q[i], pq.q[m] = pq.q[m], pq.q[i]
+		i = m
 	}
-	sort.Slice(keys, func(i, j int) bool {
-		return less(keys[i], keys[j])
-	})
-	return keys
 }
 
 type Vec2 struct {
@@ -219,6 +216,11 @@ func (v Vec2) Scale(s int) Vec2 {
 	return Vec2{v.X * s, v.Y * s}
 }
 
+// Mod wraps v into the box from the origin to size, exclusive.
+func (v Vec2) Mod(size Vec2) Vec2 {
+	return Vec2{((v.X % size.X) + size.X) % size.X, ((v.Y % size.Y) + size.Y) % size.Y}
+}
+
 type Vec3 struct {
 	X, Y, Z int
 }
@@ -302,65 +304,144 @@ type Input struct {
 	R1 []string
 	R2
 	I2
+	views *views
 }
 
+// Deprecated: use LoadInput(p, Lines).
 func NewInput(p string) Input {
 	return NewInputEx(p, "\n", false)
 }
 
+// Deprecated: use LoadInput(p, Digits).
 func NewInputI(p string) Input {
 	return NewInputEx(p, "\n", true)
 }
 
+// Deprecated: use LoadInput(p, Chars).
 func NewInputS(p string) Input {
 	return NewInputEx(p, "", false)
 }
 
+// Deprecated: use LoadInput(p, Words).
 func NewInputSS(p string) Input {
 	return NewInputEx(p, " ", false)
 }
 
+// Deprecated: use LoadInput(p, Sections).
 func NewInputD(p string) Input {
 	return NewInputEx(p, "\n\n", false)
 }
 
 func NewInputEx(p string, sep string, i2 bool) Input {
-	var a2d Input
-	data := ReadFile(p)
+	input, err := LoadInput(p, InputOptions{Sep: sep, Ints: i2})
+	if err != nil {
+		panic(err)
+	}
+	return input
+}
+
+var ErrEmptyInput = errors.New("empty input")
+
+// NumberError reports a cell of an integer grid that is not a number.
+// Line and Col are 1-based, Line counts records split by Sep.
+type NumberError struct {
+	Path      string
+	Line, Col int
+	Text      string
+	Err       error
+}
+
+func (e *NumberError) Error() string {
+	pos := fmt.Sprintf("%d:%d", e.Line, e.Col)
+	if e.Path != "" {
+		pos = e.Path + ":" + pos
+	}
+	return fmt.Sprintf("%s: invalid number %q", pos, e.Text)
+}
+
+func (e *NumberError) Unwrap() error {
+	return e.Err
+}
+
+type InputOptions struct {
+	// Sep splits the input into R1 and B1, an empty Sep splits
+	// into single characters.
+	Sep string
+	// Ints parses every character of every record into I2.
+	Ints bool
+	// AllowEmpty accepts inputs that are empty after trimming.
+	AllowEmpty bool
+}
+
+// LoadInput reads p like LoadFile and parses it with opts. Errors
+// name the file that was found.
+func LoadInput(p string, opts InputOptions) (Input, error) {
+	data, found, err := DefaultInputs.ReadPath(p)
+	if err != nil {
+		return Input{}, err
+	}
+	return parseFile(found, data, opts)
+}
+
+// parseFile parses data read from the file at p, naming p in errors.
+func parseFile(p string, data []byte, opts InputOptions) (Input, error) {
+	input, err := ParseInput(data, opts)
+	var nerr *NumberError
+	switch {
+	case errors.As(err, &nerr):
+		nerr.Path = p
+	case err != nil:
+		err = fmt.Errorf("%s: %w", p, err)
+	}
+	return input, err
+}
+
+func ParseInput(data []byte, opts InputOptions) (Input, error) {
+	a2d := Input{views: new(views)}
 	a2d.B = bytes.ReplaceAll(data, []byte{13}, []byte{})
 	a2d.B = bytes.TrimSpace(a2d.B)
+	if len(a2d.B) == 0 && !opts.AllowEmpty {
+		return a2d, ErrEmptyInput
+	}
 	ss := strings.TrimSpace(string(a2d.B))
-	a2d.R1 = strings.Split(ss, sep)
-	a2d.B1 = bytes.Split(a2d.B, []byte(sep))
+	a2d.R1 = strings.Split(ss, opts.Sep)
+	a2d.B1 = bytes.Split(a2d.B, []byte(opts.Sep))
 
 	a2d.R2 = make([][]string, len(a2d.R1))
-	if i2 {
+	if opts.Ints {
 		a2d.I2 = make([][]int, len(a2d.R1))
 	}
 	for i, v := range a2d.R1 {
 		a2d.R2[i] = strings.Split(v, "")
-		if i2 {
+		if opts.Ints {
 			a2d.I2[i] = make([]int, len(a2d.R2[i]))
 			for j, vv := range a2d.R2[i] {
-				n, _ := strconv.Atoi(strings.TrimSpace(vv))
+				n, err := ParseNumber(vv)
+				if err != nil {
+					return a2d, &NumberError{Line: i + 1, Col: j + 1, Text: vv, Err: err}
+				}
 				a2d.I2[i][j] = n
 			}
 		}
 	}
-	return a2d
+	return a2d, nil
 }
 
 func ReadFile(p string) []byte {
-	if len(os.Args) > 1 {
-		p = filepath.Join(os.Args[1], p)
-	}
-	data, err := os.ReadFile(p)
+	data, err := LoadFile(p)
 	if err != nil {
 		panic(err)
 	}
 	return data
 }
 
+// LoadFile reads p, looking for it in the roots and FS of
+// DefaultInputs as well if it is relative. See Inputs.ReadPath.
+func LoadFile(p string) ([]byte, error) {
+	data, _, err := DefaultInputs.ReadPath(p)
+	return data, err
+}
+
 func out[T any](r [][]T) {
 	for _, v := range r {
 		fmt.Println(v)
@@ -368,33 +449,31 @@ func out[T any](r [][]T) {
 	fmt.Println()
 }
 
+// Ex expects Fn, or CtxFn if it is set, to return Want.
 type Ex[T comparable] struct {
-	Want T
-	Fn   func() T
+	Want  T
+	Fn    func() T
+	CtxFn func(*Ctx) T
 }
 
-func Puzzle[T comparable](expected ...Ex[T]) {
-	Expect("Puzzle ", expected...)
+func Puzzle[T comparable](expected ...Ex[T]) []Result {
+	return Expect(PuzzleName, expected...)
 }
 
-func Example[T comparable](expected ...Ex[T]) {
-	Expect("Example", expected...)
+func Example[T comparable](expected ...Ex[T]) []Result {
+	return Expect(ExampleName, expected...)
 }
 
-func Expect[T comparable](name string, expected ...Ex[T]) {
+func Expect[T comparable](name string, expected ...Ex[T]) []Result {
+	results := make([]Result, len(expected))
 	for i, v := range expected {
-		ExpectRun(name, i+1, v)
+		results[i] = ExpectRun(name, i+1, v)
 	}
+	return results
 }
 
-func ExpectRun[T comparable](name string, i int, expected Ex[T]) {
-	start := time.Now()
-	got := expected.Fn()
-	end := time.Since(start)
-	if got != expected.Want {
-		panic(fmt.Sprintf("%s %d failed\nGot : %v\nWant: %v", name, i, got, expected.Want))
-	}
-	fmt.Printf("%s %d: %v (%s)\n", name, i, got, end.String())
+func ExpectRun[T comparable](name string, i int, expected Ex[T]) Result {
+	return check(context.Background(), DefaultReport, Result{Name: name, Part: i}, expected)
 }
 
 func ExpectPeek(b []byte, i int, expected string) bool {
@@ -425,13 +504,17 @@ func ValidIndicies(rows, cols, x, y int) bool {
 }
 
 func Number(s string) int {
-	n, err := strconv.Atoi(strings.TrimSpace(s))
+	n, err := ParseNumber(s)
 	if err != nil {
 		panic(err)
 	}
 	return n
 }
 
+func ParseNumber(s string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(s))
+}
+
 func AbsInt(x int) int {
 	if x < 0 {
 		return -x
@@ -469,7 +552,7 @@ func Djikstra(g Djikstrable, start Vec2, end Vec2) []Vec2 {
 	q := NewPrio(func(a, b Vec3) bool {
 		return a.Z < b.Z
 	})
-	q.Push((start.Vec3(0)))
+	q.Add(start.Vec3(0))
 
 	for !q.Empty() {
 		cur := q.Next()
@@ -0,0 +1,83 @@
+// Package cltest runs registered days and expectations as Go tests,
+// keeping the testing package out of the cl library.
+package cltest
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lindeneg/aoc/cl"
+)
+
+// Day runs every case of d as a subtest named after its kind and part,
+// e.g. Example/1 or Puzzle/2, with inputs found from dir. Examples are
+// checked in, so one that cannot be loaded fails. Puzzles without an
+// input file or a recorded answer are skipped.
+func Day(t *testing.T, d *cl.Day, dir string) {
+	t.Helper()
+	byName := make(map[string][]cl.Prepared)
+	for _, pc := range d.Prepare(dir) {
+		byName[pc.Case.Name] = append(byName[pc.Case.Name], pc)
+	}
+	for _, name := range []string{cl.ExampleName, cl.PuzzleName} {
+		if len(byName[name]) == 0 {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			for _, pc := range byName[name] {
+				t.Run(strconv.Itoa(pc.Part.N), func(t *testing.T) {
+					run(t, pc)
+				})
+			}
+		})
+	}
+}
+
+func run(t *testing.T, pc cl.Prepared) {
+	t.Helper()
+	puzzle := pc.Case.Name == cl.PuzzleName
+	switch {
+	case puzzle && errors.Is(pc.Err, fs.ErrNotExist):
+		t.Skip(pc.Err)
+	case pc.Err != nil:
+		t.Fatal(pc.Err)
+	case !pc.Answered:
+		if cl.Answering == cl.VerifyAnswers {
+			t.Fatalf("no answer recorded in %s", cl.AnswersFile)
+		}
+		t.Skipf("no answer recorded in %s", cl.AnswersFile)
+	}
+	Expect(t, cl.Ex[any]{Want: pc.Want, CtxFn: pc.Fn})
+}
+
+// Run runs every expectation as the subtest name/i, mirroring the
+// numbering used by cl.Expect.
+func Run[T comparable](t *testing.T, name string, expected ...cl.Ex[T]) {
+	t.Helper()
+	t.Run(name, func(t *testing.T) {
+		for i, v := range expected {
+			t.Run(strconv.Itoa(i+1), func(t *testing.T) {
+				Expect(t, v)
+			})
+		}
+	})
+}
+
+// Expect fails t if e does not produce its wanted value.
+func Expect[T comparable](t *testing.T, e cl.Ex[T]) {
+	t.Helper()
+	start := time.Now()
+	got, msg := e.Call(context.Background(), t.Name())
+	if msg != "" {
+		t.Fatalf("panic: %s", msg)
+	}
+	if got != e.Want {
+		t.Errorf("\nGot : %v\nWant: %v", got, e.Want)
+		return
+	}
+	t.Logf("%v (%s)", got, time.Since(start))
+}
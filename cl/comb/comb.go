@@ -0,0 +1,207 @@
+// Package comb generates permutations, combinations and friends
+// lazily. The generators yield the same slice on every step and
+// overwrite it on the next, so clone it to keep it.
+package comb
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/lindeneg/aoc/cl/num"
+)
+
+// Permutations yields every ordering of s, in the order of Heap's
+// algorithm. s itself is left untouched.
+func Permutations[T any](s []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		a := slices.Clone(s)
+		c := make([]int, len(a))
+		if !yield(a) {
+			return
+		}
+		for i := 0; i < len(a); {
+			if c[i] >= i {
+				c[i] = 0
+				i++
+				continue
+			}
+			if i%2 == 0 {
+				a[0], a[i] = a[i], a[0]
+			} else {
+				a[c[i]], a[i] = a[i], a[c[i]]
+			}
+			if !yield(a) {
+				return
+			}
+			c[i]++
+			i = 0
+		}
+	}
+}
+
+// Combinations yields every choice of k elements of s, keeping their
+// order in s, in lexicographic order of their indices.
+func Combinations[T any](s []T, k int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(s)
+		if k < 0 || k > n {
+			return
+		}
+		idx := make([]int, k)
+		for i := range idx {
+			idx[i] = i
+		}
+		out := make([]T, k)
+		for {
+			for i, j := range idx {
+				out[i] = s[j]
+			}
+			if !yield(out) {
+				return
+			}
+			i := k - 1
+			for i >= 0 && idx[i] == i+n-k {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			idx[i]++
+			for j := i + 1; j < k; j++ {
+				idx[j] = idx[j-1] + 1
+			}
+		}
+	}
+}
+
+// Product yields the cartesian product of sets, the last set varying
+// fastest.
+func Product[T any](sets ...[]T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		out := make([]T, len(sets))
+		for i, s := range sets {
+			if len(s) == 0 {
+				return
+			}
+			out[i] = s[0]
+		}
+		idx := make([]int, len(sets))
+		for {
+			if !yield(out) {
+				return
+			}
+			i := len(sets) - 1
+			for ; i >= 0; i-- {
+				idx[i]++
+				if idx[i] < len(sets[i]) {
+					out[i] = sets[i][idx[i]]
+					break
+				}
+				idx[i] = 0
+				out[i] = sets[i][0]
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+// ProductN yields every sequence of n elements of s.
+func ProductN[T any](s []T, n int) iter.Seq[[]T] {
+	return Product(slices.Repeat([][]T{s}, n)...)
+}
+
+// Compositions yields every way to write n as an ordered sum of k
+// non-negative parts, e.g. 0+2, 1+1 and 2+0 for n=2 and k=2.
+func Compositions(n, k int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if n < 0 || k == 0 && n != 0 {
+			return
+		}
+		p := make([]int, k)
+		var rec func(i, left int) bool
+		rec = func(i, left int) bool {
+			if i >= k-1 {
+				if k > 0 {
+					p[i] = left
+				}
+				return yield(p)
+			}
+			for x := 0; x <= left; x++ {
+				p[i] = x
+				if !rec(i+1, left-x) {
+					return false
+				}
+			}
+			return true
+		}
+		rec(0, n)
+	}
+}
+
+// Partitions yields every way to write n as a sum of positive parts,
+// each partition in non-increasing order starting with n itself.
+func Partitions(n int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if n < 0 {
+			return
+		}
+		var p []int
+		var rec func(left, most int) bool
+		rec = func(left, most int) bool {
+			if left == 0 {
+				return yield(p)
+			}
+			for x := min(left, most); x > 0; x-- {
+				p = append(p, x)
+				if !rec(left-x, x) {
+					return false
+				}
+				p = p[:len(p)-1]
+			}
+			return true
+		}
+		rec(n, n)
+	}
+}
+
+// PowerSet yields every subset of s, each in the order of s, starting
+// with the empty set.
+func PowerSet[T any](s []T) iter.Seq[[]T] {
+	if len(s) >= 63 {
+		panic("comb: power set of more than 62 elements")
+	}
+	return func(yield func([]T) bool) {
+		out := make([]T, 0, len(s))
+		for mask := uint64(0); mask < 1<<len(s); mask++ {
+			out = out[:0]
+			for i, v := range s {
+				if mask&(1<<i) != 0 {
+					out = append(out, v)
+				}
+			}
+			if !yield(out) {
+				return
+			}
+		}
+	}
+}
+
+// Binomial returns n choose k, ok is false if it overflows an int.
+func Binomial(n, k int) (r int, ok bool) {
+	if k < 0 || k > n {
+		return 0, true
+	}
+	k = min(k, n-k)
+	r = 1
+	for i := 1; i <= k; i++ {
+		// r*(n-k+i) is divisible by i, dividing by the common factors
+		// first keeps the product small.
+		g := num.GCD(r, i)
+		if r, ok = num.MulChecked(r/g, (n-k+i)/(i/g)); !ok {
+			return 0, false
+		}
+	}
+	return r, true
+}
@@ -0,0 +1,97 @@
+package comb
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+)
+
+// collect clones every yielded slice, as the generators reuse theirs.
+func collect[T any](seq iter.Seq[[]T]) [][]T {
+	var out [][]T
+	for s := range seq {
+		out = append(out, slices.Clone(s))
+	}
+	return out
+}
+
+func TestGenerators(t *testing.T) {
+	abc := []string{"a", "b", "c"}
+	tests := []struct {
+		name string
+		got  any
+		want string
+	}{
+		{"permutations", collect(Permutations(abc)), "[[a b c] [b a c] [c a b] [a c b] [b c a] [c b a]]"},
+		{"permutations of none", collect(Permutations([]string{})), "[[]]"},
+		{"combinations", collect(Combinations(abc, 2)), "[[a b] [a c] [b c]]"},
+		{"combinations of none", collect(Combinations(abc, 0)), "[[]]"},
+		{"combinations too many", collect(Combinations(abc, 4)), "[]"},
+		{"product", collect(Product([]string{"a", "b"}, []string{"x"}, []string{"1", "2"})), "[[a x 1] [a x 2] [b x 1] [b x 2]]"},
+		{"product with empty", collect(Product([]string{"a"}, []string{})), "[]"},
+		{"product n", collect(ProductN([]string{"a", "b"}, 2)), "[[a a] [a b] [b a] [b b]]"},
+		{"compositions", collect(Compositions(2, 2)), "[[0 2] [1 1] [2 0]]"},
+		{"compositions of 3", collect(Compositions(3, 3)), "[[0 0 3] [0 1 2] [0 2 1] [0 3 0] [1 0 2] [1 1 1] [1 2 0] [2 0 1] [2 1 0] [3 0 0]]"},
+		{"partitions", collect(Partitions(4)), "[[4] [3 1] [2 2] [2 1 1] [1 1 1 1]]"},
+		{"power set", collect(PowerSet(abc)), "[[] [a] [b] [a b] [c] [a c] [b c] [a b c]]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(tt.got); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6}
+	seen := make(map[string]bool)
+	for p := range Permutations(s) {
+		seen[fmt.Sprint(p)] = true
+	}
+	if len(seen) != 720 {
+		t.Errorf("got %d distinct permutations, want 720", len(seen))
+	}
+	if !slices.Equal(s, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("s changed to %v", s)
+	}
+	n := 0
+	for range Permutations(s) {
+		if n++; n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("stopped after %d", n)
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	tests := []struct {
+		n, k, want int
+		ok         bool
+	}{
+		{5, 2, 10, true},
+		{5, 0, 1, true},
+		{5, 5, 1, true},
+		{5, 6, 0, true},
+		{5, -1, 0, true},
+		{52, 5, 2598960, true},
+		{66, 33, 7219428434016265740, true},
+		{67, 33, 0, false},
+		{1000, 999, 1000, true},
+	}
+	for _, tt := range tests {
+		if got, ok := Binomial(tt.n, tt.k); got != tt.want || ok != tt.ok {
+			t.Errorf("Binomial(%d, %d) = %d, %v, want %d, %v", tt.n, tt.k, got, ok, tt.want, tt.ok)
+		}
+	}
+	for n := range 8 {
+		for k := range n + 1 {
+			want, _ := Binomial(n, k)
+			if got := len(collect(Combinations(make([]int, n), k))); got != want {
+				t.Errorf("%d combinations of %d, want %d", got, n, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package cl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// Debug enables the Debugf output of solvers.
+	Debug bool
+	// Stderr receives the progress and debug output of solvers.
+	Stderr io.Writer = os.Stderr
+	// VizDir is where solvers save pictures, see Ctx.VizPath.
+	VizDir string
+)
+
+// progressEvery limits how often a progress line is redrawn.
+const progressEvery = 100 * time.Millisecond
+
+// active counts the solvers running under timed. Redrawing a line in
+// place only works for one of them at a time.
+var active atomic.Int32
+
+// Ctx is handed to solvers registered with SolveCtx. Its context is
+// done when the case runs out of time, which long searches should
+// check now and then to give up early.
+type Ctx struct {
+	context.Context
+	label    string
+	name     string
+	tty      bool
+	quiet    bool
+	start    time.Time
+	mu       sync.Mutex
+	last     time.Time
+	drawn    bool
+	finished bool
+	memos    []func() MemoStats
+}
+
+func newCtx(ctx context.Context, label string) *Ctx {
+	return &Ctx{Context: ctx, label: label, tty: IsTerminal(Stderr), start: time.Now()}
+}
+
+// quietCtx is a Ctx without any output, used for repeated runs.
+func quietCtx() *Ctx {
+	c := newCtx(context.Background(), "")
+	c.quiet = true
+	return c
+}
+
+// Progress reports that cur out of total steps are done, drawn on
+// Stderr with an estimate of the time left if it is a terminal. A
+// total of 0 means it is not known, the rate is shown instead.
+func (c *Ctx) Progress(cur, total int) {
+	if c.quiet || !c.terminal() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.finished {
+		return
+	}
+	now := time.Now()
+	if c.drawn && now.Sub(c.last) < progressEvery {
+		return
+	}
+	c.last, c.drawn = now, true
+	elapsed := now.Sub(c.start)
+	s := fmt.Sprintf("%s: %d", c.label, cur)
+	switch {
+	case total > 0:
+		s += fmt.Sprintf("/%d (%.1f%%)", total, 100*float64(cur)/float64(total))
+		if cur > 0 {
+			left := elapsed * time.Duration(total-cur) / time.Duration(cur)
+			s += fmt.Sprintf(" ETA %s", left.Round(time.Second))
+		}
+	case elapsed > 0:
+		s += fmt.Sprintf(" (%.0f/s)", float64(cur)/elapsed.Seconds())
+	}
+	fmt.Fprintf(Stderr, "\r\x1b[K%s", s)
+}
+
+// Debugf writes a line to Stderr if Debug is set.
+func (c *Ctx) Debugf(format string, args ...any) {
+	if Debug {
+		c.Logf(format, args...)
+	}
+}
+
+// Logf writes a line to Stderr, for problems that should not fail
+// the case, such as a picture that could not be saved.
+func (c *Ctx) Logf(format string, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.quiet || c.finished {
+		return
+	}
+	c.clear()
+	fmt.Fprintf(Stderr, "%s: %s\n", c.label, fmt.Sprintf(format, args...))
+}
+
+// Debugging reports whether Debug is set and c still has a case to
+// report on, for solvers whose debug output is more than Debugf lines.
+func (c *Ctx) Debugging() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Debug && !c.quiet && !c.finished
+}
+
+// Output returns a writer to Stderr that drops everything once c is
+// finished. It counts as a terminal only while Stderr is one and no
+// other case is running.
+func (c *Ctx) Output() io.Writer {
+	return ctxWriter{c}
+}
+
+type ctxWriter struct {
+	c *Ctx
+}
+
+func (w ctxWriter) Write(p []byte) (int, error) {
+	w.c.mu.Lock()
+	defer w.c.mu.Unlock()
+	if w.c.quiet || w.c.finished {
+		return len(p), nil
+	}
+	w.c.clear()
+	return Stderr.Write(p)
+}
+
+func (w ctxWriter) IsTerminal() bool {
+	return w.c.terminal()
+}
+
+func (c *Ctx) terminal() bool {
+	return c.tty && active.Load() <= 1
+}
+
+// VizPath returns a path in VizDir named after the case with ext
+// appended, e.g. 2024-15-example2-1.gif for part 1 of example2.in,
+// or "" if VizDir is not set.
+func (c *Ctx) VizPath(ext string) string {
+	if VizDir == "" || c.quiet {
+		return ""
+	}
+	name := c.name
+	if name == "" {
+		name = strings.ToLower(strings.NewReplacer("/", "-", " ", "-").Replace(c.label))
+	}
+	return filepath.Join(VizDir, name+ext)
+}
+
+func (c *Ctx) track(stats func() MemoStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.memos = append(c.memos, stats)
+}
+
+// memoStats adds up the lookups of the memos made for c, it must not
+// be called while the solver is running. It returns nil if there were
+// none.
+func (c *Ctx) memoStats() *MemoStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var s MemoStats
+	for _, stats := range c.memos {
+		s = s.add(stats())
+	}
+	if s == (MemoStats{}) {
+		return nil
+	}
+	return &s
+}
+
+// finish removes the progress line and silences c, which matters for
+// a solver still running after its case timed out.
+func (c *Ctx) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clear()
+	c.finished = true
+}
+
+func (c *Ctx) clear() {
+	if c.drawn {
+		fmt.Fprint(Stderr, "\r\x1b[K")
+		c.drawn = false
+	}
+}
+
+// IsTerminal reports whether w is a character device such as a
+// terminal, or claims to be one through an IsTerminal method.
+func IsTerminal(w io.Writer) bool {
+	if t, ok := w.(interface{ IsTerminal() bool }); ok {
+		return t.IsTerminal()
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// Call runs e with a Ctx labelled label, returning the message of
+// its panic instead of a value if it panics.
+func (e Ex[T]) Call(ctx context.Context, label string) (T, string) {
+	c := newCtx(ctx, label)
+	defer c.finish()
+	return protect(func() T { return e.run(c) })
+}
+
+func (e Ex[T]) run(c *Ctx) T {
+	if e.CtxFn != nil {
+		return e.CtxFn(c)
+	}
+	return e.Fn()
+}
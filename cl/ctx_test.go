@@ -0,0 +1,178 @@
+package cl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ttyBuffer is a buffer that passes for a terminal.
+type ttyBuffer struct {
+	bytes.Buffer
+}
+
+func (*ttyBuffer) IsTerminal() bool { return true }
+
+// withStderr points Stderr at w and sets Debug and VizDir for the
+// rest of the test.
+func withStderr(t *testing.T, w io.Writer, debug bool, vizDir string) {
+	t.Helper()
+	stderr, dbg, viz := Stderr, Debug, VizDir
+	t.Cleanup(func() { Stderr, Debug, VizDir = stderr, dbg, viz })
+	Stderr, Debug, VizDir = w, debug, vizDir
+}
+
+func TestCtxProgress(t *testing.T) {
+	tests := []struct {
+		name  string
+		tty   bool
+		total int
+		want  string
+	}{
+		{"total", true, 10, "\r\x1b[Kt: 5/10 (50.0%) ETA "},
+		{"no total", true, 0, "\r\x1b[Kt: 5 ("},
+		{"not a terminal", false, 10, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			var w io.Writer = out
+			if tt.tty {
+				tty := &ttyBuffer{}
+				out, w = &tty.Buffer, tty
+			}
+			withStderr(t, w, false, "")
+			c := newCtx(context.Background(), "t")
+			c.Progress(5, tt.total)
+			// redrawn at most every progressEvery
+			c.Progress(6, tt.total)
+			got := out.String()
+			if !strings.HasPrefix(got, tt.want) || strings.Count(got, "\r") > 1 || (tt.want == "" && got != "") {
+				t.Errorf("got %q, want it to start with %q", got, tt.want)
+			}
+			c.finish()
+			if tt.tty && !strings.HasSuffix(out.String(), "\r\x1b[K") {
+				t.Errorf("finish left the progress line: %q", out.String())
+			}
+			n := len(out.String())
+			c.Progress(7, tt.total)
+			if len(out.String()) != n {
+				t.Errorf("progress drawn after finish: %q", out.String())
+			}
+		})
+	}
+}
+
+func TestCtxDebug(t *testing.T) {
+	tests := []struct {
+		name  string
+		debug bool
+		quiet bool
+		want  string
+	}{
+		{"debug", true, false, "t: debug 1\nt: log 2\n"},
+		{"no debug", false, false, "t: log 2\n"},
+		{"quiet", true, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			withStderr(t, &out, tt.debug, "")
+			c := newCtx(context.Background(), "t")
+			c.quiet = tt.quiet
+			if c.Debugging() != (tt.debug && !tt.quiet) {
+				t.Errorf("Debugging() = %v", c.Debugging())
+			}
+			c.Debugf("debug %d", 1)
+			c.Logf("log %d", 2)
+			if got := out.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			c.finish()
+			out.Reset()
+			c.Debugf("late")
+			c.Logf("late")
+			fmt.Fprint(c.Output(), "late")
+			if out.Len() != 0 || c.Debugging() {
+				t.Errorf("wrote %q after finish", out.String())
+			}
+		})
+	}
+}
+
+func TestCtxOutput(t *testing.T) {
+	out := &ttyBuffer{}
+	withStderr(t, out, false, "")
+	c := newCtx(context.Background(), "t")
+	c.Progress(1, 2)
+	w := c.Output()
+	if !IsTerminal(w) {
+		t.Error("Output of a terminal is not a terminal")
+	}
+	fmt.Fprint(w, "frame")
+	// the progress line is cleared before the frame
+	if got := out.String(); !strings.HasSuffix(got, "\r\x1b[Kframe") {
+		t.Errorf("got %q", got)
+	}
+	active.Add(2)
+	defer active.Add(-2)
+	if IsTerminal(w) {
+		t.Error("Output is a terminal while other cases run")
+	}
+}
+
+func TestCtxVizPath(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		vizDir string
+		res    Result
+		quiet  bool
+		want   string
+	}{
+		{"no dir", "", Result{Year: 2024, Day: 15, Name: ExampleName, Part: 1, File: "example2.in"}, false, ""},
+		{"file", dir, Result{Year: 2024, Day: 15, Name: ExampleName, Part: 1, File: "example2.in"}, false, "2024-15-example2-1.gif"},
+		{"label", dir, Result{Year: 2024, Day: 15, Name: ExampleName, Part: 2}, false, "2024-15-example-2.gif"},
+		{"quiet", dir, Result{Year: 2024, Day: 15, Name: ExampleName, Part: 1}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withStderr(t, &bytes.Buffer{}, false, tt.vizDir)
+			var got string
+			timed(context.Background(), &tt.res, func(c *Ctx) int {
+				c.quiet = tt.quiet
+				got = c.VizPath(".gif")
+				return 0
+			})
+			want := tt.want
+			if want != "" {
+				want = filepath.Join(dir, want)
+			}
+			if got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCtxMemoStats(t *testing.T) {
+	c := newCtx(context.Background(), "t")
+	if s := c.memoStats(); s != nil {
+		t.Errorf("stats %v without memos", s)
+	}
+	a, b := NewMemo[int, int](c, 0), NewMemo[string, int](c, 1)
+	a.Get(1)
+	a.Set(1, 1)
+	a.Get(1)
+	b.Set("x", 1)
+	b.Set("y", 2)
+	b.Get("x")
+	want := MemoStats{Hits: 1, Misses: 2, Evictions: 1}
+	if s := c.memoStats(); s == nil || *s != want {
+		t.Errorf("stats %v, want %v", s, want)
+	}
+}
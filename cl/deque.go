@@ -0,0 +1,126 @@
+package cl
+
+import "iter"
+
+// Deque is a double-ended queue in a ring buffer that grows and
+// shrinks with the number of elements.
+type Deque[T any] struct {
+	buf     []T
+	head, n int
+}
+
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+func (d *Deque[T]) Len() int { return d.n }
+
+func (d *Deque[T]) Empty() bool {
+	return d.n == 0
+}
+
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.index(d.n)] = v
+	d.n++
+}
+
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront removes the first element, ok is false if d is empty.
+func (d *Deque[T]) PopFront() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	v = d.buf[d.head]
+	var zero T
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+// PopBack removes the last element, ok is false if d is empty.
+func (d *Deque[T]) PopBack() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	i := d.index(d.n - 1)
+	v = d.buf[i]
+	var zero T
+	d.buf[i] = zero
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+func (d *Deque[T]) PeekFront() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	return d.buf[d.head], true
+}
+
+func (d *Deque[T]) PeekBack() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	return d.buf[d.index(d.n-1)], true
+}
+
+// At returns the i-th element from the front.
+func (d *Deque[T]) At(i int) T {
+	AssertM(i >= 0 && i < d.n, "index %d out of range [0:%d]", i, d.n)
+	return d.buf[d.index(i)]
+}
+
+func (d *Deque[T]) Clear() {
+	d.buf, d.head, d.n = nil, 0, 0
+}
+
+// All yields the elements from front to back.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.n {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+func (d *Deque[T]) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+	d.resize(max(2*len(d.buf), 8))
+}
+
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > 8 && d.n <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// resize moves the elements to the front of a buffer of size n,
+// which must be a power of two.
+func (d *Deque[T]) resize(n int) {
+	buf := make([]T, n)
+	if d.head+d.n <= len(d.buf) {
+		copy(buf, d.buf[d.head:d.head+d.n])
+	} else {
+		k := copy(buf, d.buf[d.head:])
+		copy(buf[k:], d.buf[:d.n-k])
+	}
+	d.buf, d.head = buf, 0
+}
@@ -0,0 +1,97 @@
+package cl
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// TestDequeModel checks a Deque against a slice through random pushes
+// and pops, which wraps the ring around and grows and shrinks it.
+func TestDequeModel(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	var d Deque[int]
+	var model []int
+	for i := range 20000 {
+		// favour pushes for the first half and pops for the second
+		push := r.IntN(100) < 70
+		if i >= 10000 {
+			push = !push
+		}
+		switch {
+		case push && r.IntN(2) == 0:
+			d.PushBack(i)
+			model = append(model, i)
+		case push:
+			d.PushFront(i)
+			model = slices.Insert(model, 0, i)
+		case r.IntN(2) == 0:
+			v, ok := d.PopFront()
+			if ok != (len(model) > 0) || ok && v != model[0] {
+				t.Fatalf("step %d: PopFront = %d, %v, model %v", i, v, ok, model[:min(len(model), 1)])
+			}
+			if ok {
+				model = model[1:]
+			}
+		default:
+			v, ok := d.PopBack()
+			if ok != (len(model) > 0) || ok && v != model[len(model)-1] {
+				t.Fatalf("step %d: PopBack = %d, %v", i, v, ok)
+			}
+			if ok {
+				model = model[:len(model)-1]
+			}
+		}
+		if d.Len() != len(model) {
+			t.Fatalf("step %d: len %d, want %d", i, d.Len(), len(model))
+		}
+		if i%997 == 0 && !slices.Equal(slices.Collect(d.All()), model) {
+			t.Fatalf("step %d: elements differ from the model", i)
+		}
+	}
+}
+
+func TestDequeEnds(t *testing.T) {
+	var d Deque[int]
+	if _, ok := d.PeekFront(); ok {
+		t.Error("PeekFront of an empty deque")
+	}
+	// wrap around the initial buffer of 8 without growing
+	for i := range 6 {
+		d.PushBack(i)
+	}
+	for range 4 {
+		d.PopFront()
+	}
+	for i := 6; i < 12; i++ {
+		d.PushBack(i)
+	}
+	d.PushFront(3)
+	want := []int{3, 4, 5, 6, 7, 8, 9, 10, 11}
+	if got := slices.Collect(d.All()); !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	f, _ := d.PeekFront()
+	b, _ := d.PeekBack()
+	if f != 3 || b != 11 || d.At(4) != 7 {
+		t.Errorf("front %d back %d at(4) %d", f, b, d.At(4))
+	}
+}
+
+func TestQueue(t *testing.T) {
+	q := NewQueue[int]()
+	if _, ok := q.Pop(); ok {
+		t.Error("Pop of an empty queue")
+	}
+	for i := range 3 {
+		q.Push(i)
+	}
+	for want := range 3 {
+		if v, ok := q.Pop(); !ok || v != want {
+			t.Errorf("Pop = %d, %v, want %d", v, ok, want)
+		}
+	}
+	if !q.Empty() {
+		t.Error("queue not empty")
+	}
+}
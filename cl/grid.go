@@ -0,0 +1,272 @@
+package cl
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// Grid is a rectangular grid stored row by row in Cells. Grids share
+// their cells when copied, use Clone for an independent copy.
+type Grid[T any] struct {
+	W, H  int
+	Cells []T
+}
+
+func NewGrid[T any](w, h int) Grid[T] {
+	return Grid[T]{W: w, H: h, Cells: make([]T, w*h)}
+}
+
+// GridOf copies rows into a grid, all rows must have the same length.
+func GridOf[T any](rows [][]T) Grid[T] {
+	if len(rows) == 0 {
+		return Grid[T]{}
+	}
+	g := NewGrid[T](len(rows[0]), len(rows))
+	for y, row := range rows {
+		AssertM(len(row) == g.W, "row %d has length %d, want %d", y, len(row), g.W)
+		copy(g.Cells[y*g.W:], row)
+	}
+	return g
+}
+
+// ParseGrid converts every byte of the lines of in with fn.
+func ParseGrid[T any](in Input, fn func(b byte) T) Grid[T] {
+	rows := in.Rows()
+	g := NewGrid[T](len(rows[0]), len(rows))
+	for y, row := range rows {
+		AssertM(len(row) == g.W, "line %d has length %d, want %d", y+1, len(row), g.W)
+		for x, b := range row {
+			g.Cells[y*g.W+x] = fn(b)
+		}
+	}
+	return g
+}
+
+func ByteGrid(in Input) Grid[byte] {
+	return ParseGrid(in, func(b byte) byte { return b })
+}
+
+// DigitGrid parses a grid of single digits. It panics with a
+// *NumberError at the first cell that is not a digit.
+func DigitGrid(in Input) Grid[int] {
+	g := ParseGrid(in, func(b byte) int { return int(b) - '0' })
+	for i, d := range g.Cells {
+		if d < 0 || d > 9 {
+			panic(&NumberError{Line: i/g.W + 1, Col: i%g.W + 1, Text: string(rune(d + '0')), Err: strconv.ErrSyntax})
+		}
+	}
+	return g
+}
+
+func (g Grid[T]) In(v Vec2) bool {
+	return v.X >= 0 && v.X < g.W && v.Y >= 0 && v.Y < g.H
+}
+
+// At returns the cell at v without checking bounds.
+func (g Grid[T]) At(v Vec2) T {
+	return g.Cells[v.Y*g.W+v.X]
+}
+
+// Get returns the cell at v and whether v is inside the grid.
+func (g Grid[T]) Get(v Vec2) (T, bool) {
+	if !g.In(v) {
+		var zero T
+		return zero, false
+	}
+	return g.Cells[v.Y*g.W+v.X], true
+}
+
+// Set stores x at v and reports whether v is inside the grid.
+func (g Grid[T]) Set(v Vec2, x T) bool {
+	if !g.In(v) {
+		return false
+	}
+	g.Cells[v.Y*g.W+v.X] = x
+	return true
+}
+
+// Wrap maps v onto the grid as if it repeated in every direction.
+func (g Grid[T]) Wrap(v Vec2) Vec2 {
+	return v.Mod(Vec2{g.W, g.H})
+}
+
+// Pos returns the position of the cell at index i of Cells.
+func (g Grid[T]) Pos(i int) Vec2 {
+	return Vec2{i % g.W, i / g.W}
+}
+
+// Neighbors4 yields the orthogonal neighbors of v inside the grid,
+// clockwise starting with the one above.
+func (g Grid[T]) Neighbors4(v Vec2) iter.Seq[Vec2] {
+	return g.neighbors(v, dirs4[:])
+}
+
+// Neighbors8 yields all neighbors of v inside the grid, clockwise
+// starting with the one above.
+func (g Grid[T]) Neighbors8(v Vec2) iter.Seq[Vec2] {
+	return g.neighbors(v, dirs8[:])
+}
+
+func (g Grid[T]) neighbors(v Vec2, dirs []Vec2) iter.Seq[Vec2] {
+	return func(yield func(Vec2) bool) {
+		for _, d := range dirs {
+			if n := v.Add(d); g.In(n) && !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// All yields every cell in row order.
+func (g Grid[T]) All() iter.Seq2[Vec2, T] {
+	return func(yield func(Vec2, T) bool) {
+		for i, c := range g.Cells {
+			if !yield(g.Pos(i), c) {
+				return
+			}
+		}
+	}
+}
+
+// Keys yields every position in row order.
+func (g Grid[T]) Keys() iter.Seq[Vec2] {
+	return func(yield func(Vec2) bool) {
+		for i := range g.Cells {
+			if !yield(g.Pos(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values yields every cell in row order.
+func (g Grid[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, c := range g.Cells {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// Line yields the cells from v in steps of step until it leaves the
+// grid, e.g. V2(1, 1) walks a diagonal.
+func (g Grid[T]) Line(v, step Vec2) iter.Seq2[Vec2, T] {
+	return func(yield func(Vec2, T) bool) {
+		for ; g.In(v); v = v.Add(step) {
+			if !yield(v, g.At(v)) {
+				return
+			}
+		}
+	}
+}
+
+func (g Grid[T]) Row(y int) iter.Seq2[Vec2, T] {
+	return g.Line(Vec2{0, y}, Vec2{1, 0})
+}
+
+func (g Grid[T]) Col(x int) iter.Seq2[Vec2, T] {
+	return g.Line(Vec2{x, 0}, Vec2{0, 1})
+}
+
+// Diag walks down and to the right from v.
+func (g Grid[T]) Diag(v Vec2) iter.Seq2[Vec2, T] {
+	return g.Line(v, Vec2{1, 1})
+}
+
+// AntiDiag walks down and to the left from v.
+func (g Grid[T]) AntiDiag(v Vec2) iter.Seq2[Vec2, T] {
+	return g.Line(v, Vec2{-1, 1})
+}
+
+// Find returns the first cell in row order that matches fn.
+func (g Grid[T]) Find(fn func(T) bool) (Vec2, bool) {
+	for i, c := range g.Cells {
+		if fn(c) {
+			return g.Pos(i), true
+		}
+	}
+	return Vec2{}, false
+}
+
+func (g Grid[T]) Count(fn func(T) bool) int {
+	n := 0
+	for _, c := range g.Cells {
+		if fn(c) {
+			n++
+		}
+	}
+	return n
+}
+
+func (g Grid[T]) Clone() Grid[T] {
+	c := g
+	c.Cells = append([]T(nil), g.Cells...)
+	return c
+}
+
+// remap builds a w by h grid whose cell at v is the cell at src(v).
+func (g Grid[T]) remap(w, h int, src func(v Vec2) Vec2) Grid[T] {
+	out := NewGrid[T](w, h)
+	for i := range out.Cells {
+		out.Cells[i] = g.At(src(out.Pos(i)))
+	}
+	return out
+}
+
+func (g Grid[T]) Transpose() Grid[T] {
+	return g.remap(g.H, g.W, func(v Vec2) Vec2 { return Vec2{v.Y, v.X} })
+}
+
+// RotateCW rotates a quarter turn clockwise.
+func (g Grid[T]) RotateCW() Grid[T] {
+	return g.remap(g.H, g.W, func(v Vec2) Vec2 { return Vec2{v.Y, g.H - 1 - v.X} })
+}
+
+// RotateCCW rotates a quarter turn counter-clockwise.
+func (g Grid[T]) RotateCCW() Grid[T] {
+	return g.remap(g.H, g.W, func(v Vec2) Vec2 { return Vec2{g.W - 1 - v.Y, v.X} })
+}
+
+// FlipH mirrors the grid left to right.
+func (g Grid[T]) FlipH() Grid[T] {
+	return g.remap(g.W, g.H, func(v Vec2) Vec2 { return Vec2{g.W - 1 - v.X, v.Y} })
+}
+
+// FlipV mirrors the grid top to bottom.
+func (g Grid[T]) FlipV() Grid[T] {
+	return g.remap(g.W, g.H, func(v Vec2) Vec2 { return Vec2{v.X, g.H - 1 - v.Y} })
+}
+
+// String prints bytes, runes and strings as they are and separates
+// other cells with spaces.
+func (g Grid[T]) String() string {
+	var sb strings.Builder
+	for i, c := range g.Cells {
+		if i > 0 && i%g.W == 0 {
+			sb.WriteByte('\n')
+		}
+		switch c := any(c).(type) {
+		case byte:
+			sb.WriteByte(c)
+		case rune:
+			sb.WriteRune(c)
+		case string:
+			sb.WriteString(c)
+		default:
+			if i%g.W > 0 {
+				sb.WriteByte(' ')
+			}
+			fmt.Fprint(&sb, c)
+		}
+	}
+	return sb.String()
+}
+
+func (g Grid[T]) Print() {
+	fmt.Println(g)
+	fmt.Println()
+}
@@ -0,0 +1,42 @@
+package cl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDigitGrid(t *testing.T) {
+	g := DigitGrid(Input{B: []byte("012\n789")})
+	if g.W != 3 || g.H != 2 || g.At(V2(2, 1)) != 9 || g.At(V2(0, 0)) != 0 {
+		t.Errorf("got %+v", g)
+	}
+	tests := []struct {
+		in        string
+		line, col int
+		text      string
+	}{
+		{"012\n7.9", 2, 2, "."},
+		{"01\r\n789", 1, 3, "\r"},
+		{"a12", 1, 1, "a"},
+	}
+	for _, tt := range tests {
+		var nerr *NumberError
+		err := catch(func() { DigitGrid(Input{B: []byte(tt.in)}) })
+		if !errors.As(err, &nerr) {
+			t.Errorf("%q: panic %v, want a *NumberError", tt.in, err)
+			continue
+		}
+		if nerr.Line != tt.line || nerr.Col != tt.col || nerr.Text != tt.text {
+			t.Errorf("%q: got %d:%d %q, want %d:%d %q", tt.in, nerr.Line, nerr.Col, nerr.Text, tt.line, tt.col, tt.text)
+		}
+	}
+}
+
+// catch returns the error fn panics with, if any.
+func catch(fn func()) (err error) {
+	defer func() {
+		err, _ = recover().(error)
+	}()
+	fn()
+	return nil
+}
@@ -0,0 +1,171 @@
+package cl
+
+import "cmp"
+
+// IndexPrio is a min-heap of distinct keys whose priority can be
+// changed while queued, e.g. to decrease the distance of a state.
+type IndexPrio[K comparable, P cmp.Ordered] struct {
+	keys []K
+	prio []P
+	pos  map[K]int
+}
+
+func NewIndexPrio[K comparable, P cmp.Ordered]() *IndexPrio[K, P] {
+	return &IndexPrio[K, P]{pos: make(map[K]int)}
+}
+
+// Set queues k with priority p, or moves it to p if already queued.
+func (pq *IndexPrio[K, P]) Set(k K, p P) {
+	i, ok := pq.pos[k]
+	if !ok {
+		i = len(pq.keys)
+		pq.keys = append(pq.keys, k)
+		pq.prio = append(pq.prio, p)
+		pq.pos[k] = i
+		pq.up(i)
+		return
+	}
+	old := pq.prio[i]
+	pq.prio[i] = p
+	if p < old {
+		pq.up(i)
+	} else {
+		pq.down(i)
+	}
+}
+
+// Get returns the priority of k if it is queued.
+func (pq *IndexPrio[K, P]) Get(k K) (P, bool) {
+	i, ok := pq.pos[k]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	return pq.prio[i], true
+}
+
+func (pq *IndexPrio[K, P]) Peek() (K, P) {
+	return pq.keys[0], pq.prio[0]
+}
+
+func (pq *IndexPrio[K, P]) Next() (K, P) {
+	k, p := pq.keys[0], pq.prio[0]
+	pq.remove(0)
+	return k, p
+}
+
+// Remove dequeues k and reports whether it was queued.
+func (pq *IndexPrio[K, P]) Remove(k K) bool {
+	i, ok := pq.pos[k]
+	if ok {
+		pq.remove(i)
+	}
+	return ok
+}
+
+func (pq *IndexPrio[K, P]) Len() int { return len(pq.keys) }
+
+func (pq *IndexPrio[K, P]) Empty() bool {
+	return pq.Len() == 0
+}
+
+func (pq *IndexPrio[K, P]) remove(i int) {
+	n := len(pq.keys) - 1
+	delete(pq.pos, pq.keys[i])
+	if i != n {
+		pq.keys[i], pq.prio[i] = pq.keys[n], pq.prio[n]
+		pq.pos[pq.keys[i]] = i
+	}
+	var zero K
+	pq.keys[n] = zero
+	pq.keys, pq.prio = pq.keys[:n], pq.prio[:n]
+	if i != n {
+		pq.down(i)
+		pq.up(i)
+	}
+}
+
+func (pq *IndexPrio[K, P]) swap(i, j int) {
+	pq.keys[i], pq.keys[j] = pq.keys[j], pq.keys[i]
+	pq.prio[i], pq.prio[j] = pq.prio[j], pq.prio[i]
+	pq.pos[pq.keys[i]] = i
+	pq.pos[pq.keys[j]] = j
+}
+
+func (pq *IndexPrio[K, P]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if pq.prio[i] >= pq.prio[p] {
+			return
+		}
+		pq.swap(i, p)
+		i = p
+	}
+}
+
+func (pq *IndexPrio[K, P]) down(i int) {
+	n := len(pq.keys)
+	for {
+		m := i
+		if l := 2*i + 1; l < n && pq.prio[l] < pq.prio[m] {
+			m = l
+		}
+		if r := 2*i + 2; r < n && pq.prio[r] < pq.prio[m] {
+			m = r
+		}
+		if m == i {
+			return
+		}
+		pq.swap(i, m)
+		i = m
+	}
+}
+
+// BucketQueue is a monotone priority queue for small non-negative
+// integer priorities, as found in Dijkstra with small edge costs.
+// Nothing may be added below the priority last returned by Next.
+type BucketQueue[T any] struct {
+	buckets [][]T
+	cur, n  int
+}
+
+func NewBucketQueue[T any]() *BucketQueue[T] {
+	return &BucketQueue[T]{}
+}
+
+func (q *BucketQueue[T]) Add(v T, p int) {
+	AssertM(p >= q.cur, "priority %d is below the current %d", p, q.cur)
+	for len(q.buckets) <= p {
+		q.buckets = append(q.buckets, nil)
+	}
+	q.buckets[p] = append(q.buckets[p], v)
+	q.n++
+}
+
+func (q *BucketQueue[T]) Next() (T, int) {
+	q.skip()
+	b := q.buckets[q.cur]
+	v := b[len(b)-1]
+	q.buckets[q.cur] = b[:len(b)-1]
+	q.n--
+	return v, q.cur
+}
+
+func (q *BucketQueue[T]) Peek() (T, int) {
+	q.skip()
+	b := q.buckets[q.cur]
+	return b[len(b)-1], q.cur
+}
+
+func (q *BucketQueue[T]) skip() {
+	AssertM(q.n > 0, "next of an empty queue")
+	for len(q.buckets[q.cur]) == 0 {
+		q.cur++
+	}
+}
+
+func (q *BucketQueue[T]) Len() int { return q.n }
+
+func (q *BucketQueue[T]) Empty() bool {
+	return q.n == 0
+}
@@ -0,0 +1,120 @@
+package cl
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestPrio(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	pq := NewPrio(func(a, b int) bool { return a < b })
+	var want []int
+	for range 500 {
+		v := rng.IntN(100)
+		pq.Add(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+	if pq.Peek() != want[0] || pq.Len() != len(want) {
+		t.Fatalf("Peek %d Len %d", pq.Peek(), pq.Len())
+	}
+	var got []int
+	for !pq.Empty() {
+		got = append(got, pq.Next())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("heap order differs from sorted order")
+	}
+}
+
+// TestIndexPrio checks an IndexPrio against a map through random
+// inserts, priority changes in both directions and removals.
+func TestIndexPrio(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 8))
+	pq := NewIndexPrio[int, int]()
+	model := map[int]int{}
+	for i := range 5000 {
+		k := rng.IntN(50)
+		switch n := rng.IntN(10); {
+		case n < 6:
+			p := rng.IntN(1000)
+			pq.Set(k, p)
+			model[k] = p
+		case n < 8:
+			_, queued := model[k]
+			if pq.Remove(k) != queued {
+				t.Fatalf("step %d: Remove(%d) disagrees with the model", i, k)
+			}
+			delete(model, k)
+		case len(model) > 0:
+			k, p := pq.Next()
+			for mk, mp := range model {
+				if mp < p {
+					t.Fatalf("step %d: Next = %d at %d, but %d is at %d", i, k, p, mk, mp)
+				}
+			}
+			if model[k] != p {
+				t.Fatalf("step %d: Next = %d at %d, model has %d", i, k, p, model[k])
+			}
+			delete(model, k)
+		}
+		if pq.Len() != len(model) {
+			t.Fatalf("step %d: len %d, want %d", i, pq.Len(), len(model))
+		}
+	}
+	for k, p := range model {
+		if got, ok := pq.Get(k); !ok || got != p {
+			t.Errorf("Get(%d) = %d, %v, want %d", k, got, ok, p)
+		}
+	}
+}
+
+func TestIndexPrioDecrease(t *testing.T) {
+	pq := NewIndexPrio[string, int]()
+	pq.Set("a", 5)
+	pq.Set("b", 3)
+	pq.Set("c", 4)
+	pq.Set("a", 1)
+	pq.Set("b", 9)
+	var order []string
+	for !pq.Empty() {
+		k, _ := pq.Next()
+		order = append(order, k)
+	}
+	if !slices.Equal(order, []string{"a", "c", "b"}) {
+		t.Errorf("order %v", order)
+	}
+}
+
+func TestBucketQueue(t *testing.T) {
+	q := NewBucketQueue[string]()
+	q.Add("c", 3)
+	q.Add("a", 0)
+	q.Add("b", 1)
+	if v, p := q.Next(); v != "a" || p != 0 {
+		t.Errorf("Next = %s at %d", v, p)
+	}
+	q.Add("b2", 1)
+	q.Add("d", 7)
+	var got []int
+	for !q.Empty() {
+		_, p := q.Next()
+		got = append(got, p)
+	}
+	if !slices.Equal(got, []int{1, 1, 3, 7}) {
+		t.Errorf("priorities %v", got)
+	}
+	if msg := catchMsg(func() { q.Add("x", 2) }); msg == "" {
+		t.Error("adding below the current priority did not panic")
+	}
+}
+
+// catchMsg returns the message fn panics with, if any.
+func catchMsg(fn func()) string {
+	_, msg := protect(func() struct{} {
+		fn()
+		return struct{}{}
+	})
+	return msg
+}
@@ -0,0 +1,91 @@
+package cl
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// InputRootEnv holds a list of input roots, separated like PATH.
+const InputRootEnv = "AOC_INPUTS"
+
+var (
+	Lines    = InputOptions{Sep: "\n"}
+	Digits   = InputOptions{Sep: "\n", Ints: true}
+	Chars    = InputOptions{Sep: ""}
+	Words    = InputOptions{Sep: " "}
+	Sections = InputOptions{Sep: "\n\n"}
+)
+
+// Inputs resolves the input files of a day. A file is looked up in
+// the day directory first, then as <root>/<year>/<dd>/<file> in every
+// root and finally at <year>/<dd>/<file> in FS, e.g. an embed.FS.
+type Inputs struct {
+	Roots []string
+	FS    fs.FS
+}
+
+var DefaultInputs = InputsFromEnv()
+
+func InputsFromEnv() *Inputs {
+	return &Inputs{Roots: filepath.SplitList(os.Getenv(InputRootEnv))}
+}
+
+// Read returns the contents of file along with the location it was
+// read from. The error wraps fs.ErrNotExist if no location has it.
+func (in *Inputs) Read(dir string, year, day int, file string) ([]byte, string, error) {
+	rel := filepath.Join(fmt.Sprint(year), fmt.Sprintf("%02d", day), file)
+	var tried []string
+	if dir != "" {
+		tried = append(tried, filepath.Join(dir, file))
+	}
+	return in.read(file, rel, tried)
+}
+
+// ReadPath is Read for a path that is not tied to a day. p is read as
+// it is and, if it does not exist and is relative, looked up in every
+// root and then in FS, so 2024/01/puzzle.in finds the file kept under
+// an input root.
+func (in *Inputs) ReadPath(p string) ([]byte, string, error) {
+	if filepath.IsAbs(p) {
+		data, err := os.ReadFile(p)
+		return data, p, err
+	}
+	return in.read(p, p, []string{p})
+}
+
+// read tries every location in tried, then rel in every root and in
+// FS, naming file in the error if none has it.
+func (in *Inputs) read(file, rel string, tried []string) ([]byte, string, error) {
+	for _, root := range in.Roots {
+		tried = append(tried, filepath.Join(root, rel))
+	}
+	for _, p := range tried {
+		data, err := os.ReadFile(p)
+		if err == nil {
+			return data, p, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, p, err
+		}
+	}
+	if in.FS != nil {
+		p := filepath.ToSlash(rel)
+		data, err := fs.ReadFile(in.FS, p)
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return data, p, err
+		}
+		tried = append(tried, "embedded "+p)
+	}
+	return nil, file, fmt.Errorf("%s not found in %v: %w", file, tried, fs.ErrNotExist)
+}
+
+func (in *Inputs) Load(dir string, year, day int, file string, opts InputOptions) (Input, error) {
+	data, p, err := in.Read(dir, year, day, file)
+	if err != nil {
+		return Input{}, err
+	}
+	return parseFile(p, data, opts)
+}
@@ -0,0 +1,94 @@
+package cl
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestInputsLoad(t *testing.T) {
+	dir, root := t.TempDir(), t.TempDir()
+	write := func(p, data string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(dir, "a.in"), "dir")
+	write(filepath.Join(root, "2024", "05", "a.in"), "root")
+	write(filepath.Join(root, "2024", "05", "b.in"), "root")
+	write(filepath.Join(root, "2024", "05", "bad.in"), "12\n3x")
+	in := &Inputs{Roots: []string{root}, FS: fstest.MapFS{
+		"2024/05/c.in": {Data: []byte("embedded")},
+	}}
+	for file, want := range map[string]string{"a.in": "dir", "b.in": "root", "c.in": "embedded"} {
+		got, err := in.Load(dir, 2024, 5, file, Lines)
+		if err != nil || string(got.B) != want {
+			t.Errorf("%s: got %q, %v, want %q", file, got.B, err, want)
+		}
+	}
+	if _, err := in.Load(dir, 2024, 5, "d.in", Lines); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing file: %v", err)
+	}
+
+	// errors name the file on both paths that load one
+	bad := filepath.Join(root, "2024", "05", "bad.in")
+	_, err := in.Load(dir, 2024, 5, "bad.in", Digits)
+	_, err2 := LoadInput(bad, Digits)
+	for _, err := range []error{err, err2} {
+		var nerr *NumberError
+		if !errors.As(err, &nerr) || nerr.Path != bad || nerr.Line != 2 || nerr.Col != 2 {
+			t.Errorf("got %v, want a *NumberError at %s:2:2", err, bad)
+		}
+	}
+	write(filepath.Join(dir, "empty.in"), " \n")
+	_, err = in.Load(dir, 2024, 5, "empty.in", Lines)
+	if !errors.Is(err, ErrEmptyInput) || !strings.Contains(err.Error(), "empty.in") {
+		t.Errorf("got %v, want ErrEmptyInput naming the file", err)
+	}
+}
+
+// TestLoadFile checks that the path helpers look a relative path up
+// in the roots and FS of DefaultInputs.
+func TestLoadFile(t *testing.T) {
+	root := t.TempDir()
+	p := filepath.Join(root, "2024", "05", "a.in")
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte("root\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(in *Inputs) { DefaultInputs = in }(DefaultInputs)
+	DefaultInputs = &Inputs{Roots: []string{root}, FS: fstest.MapFS{
+		"2024/05/b.in": {Data: []byte("embedded\n")},
+	}}
+	tests := []struct {
+		p, want string
+	}{
+		{p, "root"},
+		{filepath.Join("2024", "05", "a.in"), "root"},
+		{"2024/05/b.in", "embedded"},
+	}
+	for _, tt := range tests {
+		data, err := LoadFile(tt.p)
+		if err != nil || strings.TrimSpace(string(data)) != tt.want {
+			t.Errorf("LoadFile(%s): got %q, %v, want %q", tt.p, data, err, tt.want)
+		}
+		if in, err := LoadInput(tt.p, Lines); err != nil || in.R1[0] != tt.want {
+			t.Errorf("LoadInput(%s): got %q, %v, want %q", tt.p, in.R1, err, tt.want)
+		}
+	}
+	for _, p := range []string{"2024/05/c.in", filepath.Join(root, "b.in")} {
+		if _, err := LoadFile(p); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("LoadFile(%s): %v, want fs.ErrNotExist", p, err)
+		}
+	}
+}
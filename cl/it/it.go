@@ -0,0 +1,110 @@
+// Package it adapts range-over-func iterators.
+package it
+
+import "iter"
+
+func Map[T, U any](seq iter.Seq[T], fn func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}
+}
+
+func Filter[T any](seq iter.Seq[T], fn func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if fn(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Pairs yields every element together with the one after it.
+func Pairs[T any](seq iter.Seq[T]) iter.Seq2[T, T] {
+	return func(yield func(T, T) bool) {
+		var prev T
+		first := true
+		for v := range seq {
+			if !first && !yield(prev, v) {
+				return
+			}
+			prev, first = v, false
+		}
+	}
+}
+
+// Windows yields every run of n consecutive elements, each in a new
+// slice.
+func Windows[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n <= 0 {
+		panic("it: window size must be positive")
+	}
+	return func(yield func([]T) bool) {
+		w := make([]T, 0, n)
+		for v := range seq {
+			if len(w) == n {
+				w = append(w[:0:0], w[1:]...)
+			}
+			w = append(w, v)
+			if len(w) == n && !yield(w) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk yields consecutive runs of n elements, the last one may be
+// shorter. Each chunk is a new slice.
+func Chunk[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n <= 0 {
+		panic("it: chunk size must be positive")
+	}
+	return func(yield func([]T) bool) {
+		var c []T
+		for v := range seq {
+			if c == nil {
+				c = make([]T, 0, n)
+			}
+			c = append(c, v)
+			if len(c) == n {
+				if !yield(c) {
+					return
+				}
+				c = nil
+			}
+		}
+		if len(c) > 0 {
+			yield(c)
+		}
+	}
+}
+
+// Zip yields elements of a and b side by side until either ends.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		for x := range a {
+			y, ok := next()
+			if !ok || !yield(x, y) {
+				return
+			}
+		}
+	}
+}
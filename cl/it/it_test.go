@@ -0,0 +1,138 @@
+package it
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+)
+
+// take formats at most n elements of seq, stopping it early.
+func take[T any](seq iter.Seq[T], n int) []string {
+	var out []string
+	for v := range seq {
+		if len(out) == n {
+			break
+		}
+		out = append(out, fmt.Sprint(v))
+	}
+	return out
+}
+
+// take2 is take for pairs, formatted as a:b.
+func take2[K, V any](seq iter.Seq2[K, V], n int) []string {
+	var out []string
+	for k, v := range seq {
+		if len(out) == n {
+			break
+		}
+		out = append(out, fmt.Sprintf("%v:%v", k, v))
+	}
+	return out
+}
+
+func ints(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := range n {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// TestAdapters runs every adapter over short inputs, with a limit to
+// check that it stops when its consumer does.
+func TestAdapters(t *testing.T) {
+	const all = 100
+	tests := []struct {
+		name string
+		got  func(limit int) []string
+		want []string
+	}{
+		{"map", func(l int) []string { return take(Map(ints(4), func(i int) int { return i * i }), l) }, []string{"0", "1", "4", "9"}},
+		{"map empty", func(l int) []string { return take(Map(ints(0), func(i int) int { return i }), l) }, nil},
+		{"filter", func(l int) []string { return take(Filter(ints(7), func(i int) bool { return i%2 == 1 }), l) }, []string{"1", "3", "5"}},
+		{"filter none", func(l int) []string { return take(Filter(ints(7), func(int) bool { return false }), l) }, nil},
+		{"enumerate", func(l int) []string { return take2(Enumerate(slices.Values([]string{"a", "b", "c"})), l) }, []string{"0:a", "1:b", "2:c"}},
+		{"pairs", func(l int) []string { return take2(Pairs(ints(4)), l) }, []string{"0:1", "1:2", "2:3"}},
+		{"pairs of one", func(l int) []string { return take2(Pairs(ints(1)), l) }, nil},
+		{"pairs empty", func(l int) []string { return take2(Pairs(ints(0)), l) }, nil},
+		{"windows", func(l int) []string { return take(Windows(ints(5), 3), l) }, []string{"[0 1 2]", "[1 2 3]", "[2 3 4]"}},
+		{"windows of one", func(l int) []string { return take(Windows(ints(3), 1), l) }, []string{"[0]", "[1]", "[2]"}},
+		{"windows exact", func(l int) []string { return take(Windows(ints(3), 3), l) }, []string{"[0 1 2]"}},
+		{"windows too large", func(l int) []string { return take(Windows(ints(3), 4), l) }, nil},
+		{"windows empty", func(l int) []string { return take(Windows(ints(0), 2), l) }, nil},
+		{"chunk", func(l int) []string { return take(Chunk(ints(6), 2), l) }, []string{"[0 1]", "[2 3]", "[4 5]"}},
+		{"chunk uneven", func(l int) []string { return take(Chunk(ints(7), 3), l) }, []string{"[0 1 2]", "[3 4 5]", "[6]"}},
+		{"chunk too large", func(l int) []string { return take(Chunk(ints(3), 5), l) }, []string{"[0 1 2]"}},
+		{"chunk empty", func(l int) []string { return take(Chunk(ints(0), 2), l) }, nil},
+		{"zip", func(l int) []string { return take2(Zip(ints(3), slices.Values([]string{"a", "b", "c"})), l) }, []string{"0:a", "1:b", "2:c"}},
+		{"zip shorter a", func(l int) []string { return take2(Zip(ints(2), slices.Values([]string{"a", "b", "c"})), l) }, []string{"0:a", "1:b"}},
+		{"zip shorter b", func(l int) []string { return take2(Zip(ints(5), slices.Values([]string{"a"})), l) }, []string{"0:a"}},
+		{"zip empty", func(l int) []string { return take2(Zip(ints(0), slices.Values([]string{"a"})), l) }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(all); !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			// The adapter must stop when its consumer does, without
+			// calling yield again.
+			if got, want := tt.got(1), tt.want[:min(1, len(tt.want))]; !slices.Equal(got, want) {
+				t.Errorf("stopped after one: got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// TestNewSlices checks that the windows and chunks kept by a caller
+// are not overwritten by later ones.
+func TestNewSlices(t *testing.T) {
+	windows := slices.Collect(Windows(ints(5), 2))
+	chunks := slices.Collect(Chunk(ints(5), 2))
+	if got := fmt.Sprint(windows); got != "[[0 1] [1 2] [2 3] [3 4]]" {
+		t.Errorf("windows %s", got)
+	}
+	if got := fmt.Sprint(chunks); got != "[[0 1] [2 3] [4]]" {
+		t.Errorf("chunks %s", got)
+	}
+}
+
+// TestZipStops checks that Zip stops pulling b once it is done.
+func TestZipStops(t *testing.T) {
+	pulled := 0
+	b := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	for range Zip(ints(3), b) {
+	}
+	if pulled > 4 {
+		t.Errorf("pulled %d elements of b for 3 of a", pulled)
+	}
+}
+
+func TestSizePanics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"windows", func() { Windows(ints(3), 0) }},
+		{"chunk", func() { Chunk(ints(3), -1) }},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", tt.name)
+				}
+			}()
+			tt.fn()
+		}()
+	}
+}
@@ -0,0 +1,155 @@
+package cl
+
+import "fmt"
+
+// MemoStats counts the lookups of a Memo.
+type MemoStats struct {
+	Hits      int `json:"hits"`
+	Misses    int `json:"misses"`
+	Evictions int `json:"evictions,omitempty"`
+}
+
+func (s MemoStats) String() string {
+	rate := 0.0
+	if n := s.Hits + s.Misses; n > 0 {
+		rate = 100 * float64(s.Hits) / float64(n)
+	}
+	str := fmt.Sprintf("%d hits, %d misses (%.1f%%)", s.Hits, s.Misses, rate)
+	if s.Evictions > 0 {
+		str += fmt.Sprintf(", %d evicted", s.Evictions)
+	}
+	return str
+}
+
+func (s MemoStats) add(o MemoStats) MemoStats {
+	return MemoStats{s.Hits + o.Hits, s.Misses + o.Misses, s.Evictions + o.Evictions}
+}
+
+type memoEntry[K comparable, V any] struct {
+	key        K
+	val        V
+	prev, next int
+}
+
+// Memo caches values by key. A Memo with a limit keeps at most that
+// many entries and evicts the least recently used one to make room.
+// It is not safe for concurrent use.
+type Memo[K comparable, V any] struct {
+	limit   int
+	index   map[K]int
+	entries []memoEntry[K, V]
+	head    int
+	stats   MemoStats
+}
+
+// NewMemo returns an empty Memo, unbounded if limit is 0. Its
+// lookups are reported with the result of the case c belongs to, c
+// may be nil.
+func NewMemo[K comparable, V any](c *Ctx, limit int) *Memo[K, V] {
+	AssertM(limit >= 0, "negative memo limit %d", limit)
+	m := &Memo[K, V]{limit: limit, index: make(map[K]int), head: -1}
+	if c != nil {
+		c.track(m.Stats)
+	}
+	return m
+}
+
+func (m *Memo[K, V]) Get(k K) (V, bool) {
+	i, ok := m.index[k]
+	if !ok {
+		m.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	m.stats.Hits++
+	if m.limit > 0 {
+		m.unlink(i)
+		m.push(i)
+	}
+	return m.entries[i].val, true
+}
+
+func (m *Memo[K, V]) Set(k K, v V) {
+	if i, ok := m.index[k]; ok {
+		m.entries[i].val = v
+		if m.limit > 0 {
+			m.unlink(i)
+			m.push(i)
+		}
+		return
+	}
+	if m.limit > 0 && len(m.entries) == m.limit {
+		// reuse the slot of the least recently used entry
+		i := m.entries[m.head].prev
+		m.unlink(i)
+		delete(m.index, m.entries[i].key)
+		m.entries[i].key, m.entries[i].val = k, v
+		m.index[k] = i
+		m.push(i)
+		m.stats.Evictions++
+		return
+	}
+	i := len(m.entries)
+	m.entries = append(m.entries, memoEntry[K, V]{key: k, val: v})
+	m.index[k] = i
+	if m.limit > 0 {
+		m.push(i)
+	}
+}
+
+// Func returns fn memoized in m. fn receives the memoized function
+// as self to make its recursive calls through it.
+func (m *Memo[K, V]) Func(fn func(self func(K) V, k K) V) func(K) V {
+	var self func(K) V
+	self = func(k K) V {
+		if v, ok := m.Get(k); ok {
+			return v
+		}
+		v := fn(self, k)
+		m.Set(k, v)
+		return v
+	}
+	return self
+}
+
+func (m *Memo[K, V]) Len() int {
+	return len(m.entries)
+}
+
+func (m *Memo[K, V]) Stats() MemoStats {
+	return m.stats
+}
+
+// Clear drops every entry but keeps the statistics.
+func (m *Memo[K, V]) Clear() {
+	clear(m.index)
+	clear(m.entries)
+	m.entries, m.head = m.entries[:0], -1
+}
+
+// push makes entry i the most recently used.
+func (m *Memo[K, V]) push(i int) {
+	e := &m.entries[i]
+	if m.head < 0 {
+		e.prev, e.next = i, i
+	} else {
+		h := &m.entries[m.head]
+		e.prev, e.next = h.prev, m.head
+		m.entries[h.prev].next = i
+		h.prev = i
+	}
+	m.head = i
+}
+
+func (m *Memo[K, V]) unlink(i int) {
+	e := m.entries[i]
+	if e.next == i {
+		m.head = -1
+		return
+	}
+	m.entries[e.prev].next = e.next
+	m.entries[e.next].prev = e.prev
+	if m.head == i {
+		m.head = e.next
+	}
+}
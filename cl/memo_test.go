@@ -0,0 +1,58 @@
+package cl
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoLRU(t *testing.T) {
+	m := NewMemo[int, int](nil, 2)
+	m.Set(1, 10)
+	m.Set(2, 20)
+	m.Get(1)
+	m.Set(3, 30)
+	if _, ok := m.Get(2); ok {
+		t.Error("2 was not evicted")
+	}
+	for k, want := range map[int]int{1: 10, 3: 30} {
+		if v, ok := m.Get(k); !ok || v != want {
+			t.Errorf("Get(%d) = %d, %v, want %d", k, v, ok, want)
+		}
+	}
+	want := MemoStats{Hits: 3, Misses: 1, Evictions: 1}
+	if s := m.Stats(); s != want || m.Len() != 2 {
+		t.Errorf("stats %+v len %d, want %+v len 2", s, m.Len(), want)
+	}
+}
+
+func TestMemoFunc(t *testing.T) {
+	calls := 0
+	fib := NewMemo[int, int](nil, 0).Func(func(self func(int) int, n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return self(n-1) + self(n-2)
+	})
+	if got := fib(50); got != 12586269025 || calls != 51 {
+		t.Errorf("fib(50) = %d in %d calls", got, calls)
+	}
+}
+
+func TestMemoCtx(t *testing.T) {
+	ex := Ex[int]{Want: 2, CtxFn: func(c *Ctx) int {
+		m := NewMemo[int, int](c, 0)
+		m.Set(1, 2)
+		v, _ := m.Get(1)
+		m.Get(3)
+		return v
+	}}
+	res := check(context.Background(), &Report{}, Result{Name: "t", Part: 1}, ex)
+	if res.Memo == nil || *res.Memo != (MemoStats{Hits: 1, Misses: 1}) {
+		t.Errorf("memo %v, want 1 hit and 1 miss", res.Memo)
+	}
+	res = check(context.Background(), &Report{}, Result{Name: "t", Part: 1}, Ex[int]{Fn: func() int { return 0 }})
+	if res.Memo != nil {
+		t.Errorf("memo %v for a case without one", res.Memo)
+	}
+}
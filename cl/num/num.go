@@ -0,0 +1,227 @@
+// Package num has integer number theory: gcds, modular arithmetic,
+// the Chinese remainder theorem and primes.
+package num
+
+import (
+	"math"
+	"math/bits"
+)
+
+// GCD returns the greatest common divisor of xs, which is never
+// negative. GCD() is 0.
+func GCD(xs ...int) int {
+	g := 0
+	for _, x := range xs {
+		a, b := abs(g), abs(x)
+		for b != 0 {
+			a, b = b, a%b
+		}
+		g = a
+	}
+	return g
+}
+
+// LCM returns the least common multiple of xs. It panics if that
+// overflows an int.
+func LCM(xs ...int) int {
+	if len(xs) == 0 {
+		return 0
+	}
+	l := abs(xs[0])
+	for _, x := range xs[1:] {
+		if l == 0 || x == 0 {
+			return 0
+		}
+		var ok bool
+		l, ok = MulChecked(l/GCD(l, x), abs(x))
+		if !ok {
+			panic("num: LCM overflows int")
+		}
+	}
+	return l
+}
+
+// ExtGCD returns g = gcd(a, b) together with x and y such that
+// a*x + b*y = g.
+func ExtGCD(a, b int) (g, x, y int) {
+	x0, y0, x1, y1 := 1, 0, 0, 1
+	for b != 0 {
+		q := a / b
+		a, b = b, a-q*b
+		x0, x1 = x1, x0-q*x1
+		y0, y1 = y1, y0-q*y1
+	}
+	if a < 0 {
+		return -a, -x0, -y0
+	}
+	return a, x0, y0
+}
+
+// Mod returns a modulo m in [0, m).
+func Mod(a, m int) int {
+	a %= m
+	if a < 0 {
+		a += m
+	}
+	return a
+}
+
+// mulMod returns a*b mod m for a and b in [0, m) without overflowing.
+func mulMod(a, b, m int) int {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	_, r := bits.Div64(hi, lo, uint64(m))
+	return int(r)
+}
+
+// ModPow returns b to the power e modulo m.
+func ModPow(b, e, m int) int {
+	if e < 0 || m <= 0 {
+		panic("num: ModPow needs e >= 0 and m > 0")
+	}
+	r, b := 1%m, Mod(b, m)
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			r = mulMod(r, b, m)
+		}
+		b = mulMod(b, b, m)
+	}
+	return r
+}
+
+// ModInv returns x in [0, m) with a*x = 1 modulo m, ok is false if a
+// and m are not coprime.
+func ModInv(a, m int) (x int, ok bool) {
+	g, x, _ := ExtGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, false
+	}
+	return Mod(x, m), true
+}
+
+// CRT returns the smallest non-negative x with x = rem[i] modulo
+// mod[i] for every i, and m, the lcm of mod, which x is unique
+// modulo. The moduli need not be coprime, ok is false if there is no
+// solution or m overflows an int.
+func CRT(rem, mod []int) (x, m int, ok bool) {
+	if len(rem) != len(mod) {
+		panic("num: CRT of different length remainders and moduli")
+	}
+	x, m = 0, 1
+	for i, r := range rem {
+		n := mod[i]
+		if n <= 0 {
+			panic("num: CRT modulus must be positive")
+		}
+		r = Mod(r, n)
+		g, p, _ := ExtGCD(m, n)
+		if (r-x)%g != 0 {
+			return 0, 0, false
+		}
+		l, ok := MulChecked(m/g, n)
+		if !ok {
+			return 0, 0, false
+		}
+		// x + m*t solves both when t = (r-x)/g * p modulo n/g.
+		ng := n / g
+		t := mulMod(Mod((r-x)/g, ng), Mod(p, ng), ng)
+		x = addMod(x, mulMod(m, t, l), l)
+		m = l
+	}
+	return x, m, true
+}
+
+func addMod(a, b, m int) int {
+	if a >= m-b {
+		return a - (m - b)
+	}
+	return a + b
+}
+
+// Sieve reports for every i up to n whether i is prime.
+func Sieve(n int) []bool {
+	prime := make([]bool, max(n+1, 0))
+	for i := 2; i <= n; i++ {
+		prime[i] = true
+	}
+	for i := 2; i*i <= n; i++ {
+		if !prime[i] {
+			continue
+		}
+		for j := i * i; j <= n; j += i {
+			prime[j] = false
+		}
+	}
+	return prime
+}
+
+// Primes returns the primes up to n in increasing order.
+func Primes(n int) []int {
+	if n < 2 {
+		return nil
+	}
+	var ps []int
+	for i, p := range Sieve(n) {
+		if p {
+			ps = append(ps, i)
+		}
+	}
+	return ps
+}
+
+// Divisors returns the positive divisors of n in increasing order.
+func Divisors(n int) []int {
+	if n <= 0 {
+		panic("num: divisors of a non-positive number")
+	}
+	var lo, hi []int
+	for i := 1; i <= n/i; i++ {
+		if n%i != 0 {
+			continue
+		}
+		lo = append(lo, i)
+		if i != n/i {
+			hi = append(hi, n/i)
+		}
+	}
+	for i := len(hi) - 1; i >= 0; i-- {
+		lo = append(lo, hi[i])
+	}
+	return lo
+}
+
+// Isqrt returns the largest r with r*r <= n.
+func Isqrt(n int) int {
+	if n < 0 {
+		panic("num: square root of a negative number")
+	}
+	if n < 2 {
+		return n
+	}
+	r := int(math.Sqrt(float64(n)))
+	for r > n/r {
+		r--
+	}
+	for r+1 <= n/(r+1) {
+		r++
+	}
+	return r
+}
+
+// MulChecked returns a*b, ok is false if it overflows an int.
+func MulChecked(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, false
+	}
+	return c, true
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,227 @@
+package num
+
+import (
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// mersenne61 is the prime 2^61-1, so products of residues overflow
+// an int.
+const mersenne61 = 1<<61 - 1
+
+func TestGCD(t *testing.T) {
+	tests := []struct {
+		xs       []int
+		gcd, lcm int
+	}{
+		{nil, 0, 0},
+		{[]int{12}, 12, 12},
+		{[]int{12, 18}, 6, 36},
+		{[]int{-12, 18}, 6, 36},
+		{[]int{4, 6, 10}, 2, 60},
+		{[]int{0, 5}, 5, 0},
+		{[]int{7, 13}, 1, 91},
+	}
+	for _, tt := range tests {
+		if got := GCD(tt.xs...); got != tt.gcd {
+			t.Errorf("GCD(%v) = %d, want %d", tt.xs, got, tt.gcd)
+		}
+		if got := LCM(tt.xs...); got != tt.lcm {
+			t.Errorf("LCM(%v) = %d, want %d", tt.xs, got, tt.lcm)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("LCM overflow did not panic")
+		}
+	}()
+	LCM(mersenne61, 5)
+}
+
+func TestExtGCD(t *testing.T) {
+	tests := [][2]int{{240, 46}, {46, 240}, {-240, 46}, {17, 0}, {0, 17}, {0, 0}, {mersenne61, 1_000_000_007}}
+	for _, tt := range tests {
+		a, b := tt[0], tt[1]
+		g, x, y := ExtGCD(a, b)
+		if want := GCD(a, b); g != want {
+			t.Errorf("ExtGCD(%d, %d): g = %d, want %d", a, b, g, want)
+		}
+		lhs := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(x)))
+		lhs.Add(lhs, new(big.Int).Mul(big.NewInt(int64(b)), big.NewInt(int64(y))))
+		if lhs.Cmp(big.NewInt(int64(g))) != 0 {
+			t.Errorf("ExtGCD(%d, %d) = %d, %d, %d: a*x + b*y = %s", a, b, g, x, y, lhs)
+		}
+	}
+}
+
+func TestMod(t *testing.T) {
+	tests := []struct{ a, m, want int }{{7, 3, 1}, {-7, 3, 2}, {-6, 3, 0}, {0, 5, 0}}
+	for _, tt := range tests {
+		if got := Mod(tt.a, tt.m); got != tt.want {
+			t.Errorf("Mod(%d, %d) = %d, want %d", tt.a, tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestModPow(t *testing.T) {
+	tests := []struct{ b, e, m int }{
+		{2, 10, 1000},
+		{-2, 3, 7},
+		{5, 0, 1},
+		{3, 200, 1_000_000_007},
+		{mersenne61 - 1, 3, mersenne61},
+		{123456789123456789, 987654321, mersenne61},
+		{math.MaxInt - 1, 1 << 40, math.MaxInt},
+	}
+	for _, tt := range tests {
+		m := big.NewInt(int64(tt.m))
+		b := new(big.Int).Mod(big.NewInt(int64(tt.b)), m)
+		want := new(big.Int).Exp(b, big.NewInt(int64(tt.e)), m).Int64()
+		if got := ModPow(tt.b, tt.e, tt.m); int64(got) != want {
+			t.Errorf("ModPow(%d, %d, %d) = %d, want %d", tt.b, tt.e, tt.m, got, want)
+		}
+	}
+}
+
+func TestModInv(t *testing.T) {
+	tests := []struct {
+		a, m int
+		ok   bool
+	}{
+		{3, 7, true},
+		{-3, 7, true},
+		{4, 6, false},
+		{123456789123456789, mersenne61, true},
+	}
+	for _, tt := range tests {
+		x, ok := ModInv(tt.a, tt.m)
+		if ok != tt.ok {
+			t.Errorf("ModInv(%d, %d): ok %v, want %v", tt.a, tt.m, ok, tt.ok)
+			continue
+		}
+		if ok && (x < 0 || x >= tt.m || mulMod(Mod(tt.a, tt.m), x, tt.m) != 1) {
+			t.Errorf("ModInv(%d, %d) = %d", tt.a, tt.m, x)
+		}
+	}
+}
+
+func TestCRT(t *testing.T) {
+	tests := []struct {
+		name     string
+		rem, mod []int
+		x, m     int
+		ok       bool
+	}{
+		{"coprime", []int{2, 3, 2}, []int{3, 5, 7}, 23, 105, true},
+		{"negative remainder", []int{-1, -1}, []int{4, 9}, 35, 36, true},
+		{"not coprime", []int{2, 4}, []int{6, 8}, 20, 24, true},
+		{"no solution", []int{1, 2}, []int{4, 6}, 0, 0, false},
+		{"overflow", []int{0, 0}, []int{mersenne61, 5}, 0, 0, false},
+		{"none", nil, nil, 0, 1, true},
+		// The 2024/14 shape: a time from its remainders modulo the
+		// width and height.
+		{"robots", []int{4, 7}, []int{101, 103}, 5054, 10403, true},
+		{"large", []int{mersenne61 - 2, 2}, []int{mersenne61, 3}, mersenne61 - 2, 3 * mersenne61, true},
+	}
+	for _, tt := range tests {
+		x, m, ok := CRT(tt.rem, tt.mod)
+		if x != tt.x || m != tt.m || ok != tt.ok {
+			t.Errorf("%s: got %d, %d, %v, want %d, %d, %v", tt.name, x, m, ok, tt.x, tt.m, tt.ok)
+		}
+	}
+}
+
+// TestCRTRandom checks CRT against big.Int on moduli whose products
+// overflow an int.
+func TestCRTRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	mods := []int{mersenne61, 3, 1_000_000_007, 998_244_353, 12, 18}
+	for range 200 {
+		mod := []int{mods[r.IntN(len(mods))], mods[r.IntN(len(mods))]}
+		want := r.Int64N(1 << 40)
+		rem := []int{int(want % int64(mod[0])), int(want % int64(mod[1]))}
+		x, m, ok := CRT(rem, mod)
+		if !ok {
+			if _, fits := MulChecked(mod[0]/GCD(mod...), mod[1]); fits {
+				t.Fatalf("CRT(%v, %v) failed", rem, mod)
+			}
+			continue
+		}
+		if m != LCM(mod...) || x < 0 || x >= m {
+			t.Fatalf("CRT(%v, %v) = %d, %d", rem, mod, x, m)
+		}
+		if w := new(big.Int).Mod(big.NewInt(want), big.NewInt(int64(m))); w.Int64() != int64(x) {
+			t.Fatalf("CRT(%v, %v) = %d, want %s", rem, mod, x, w)
+		}
+	}
+}
+
+func TestPrimes(t *testing.T) {
+	want := []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
+	if got := Primes(30); !slices.Equal(got, want) {
+		t.Errorf("Primes(30) = %v, want %v", got, want)
+	}
+	if got := Primes(1); got != nil {
+		t.Errorf("Primes(1) = %v", got)
+	}
+	if s := Sieve(-1); len(s) != 0 {
+		t.Errorf("Sieve(-1) = %v", s)
+	}
+	if n := len(Primes(1_000_000)); n != 78498 {
+		t.Errorf("%d primes below a million, want 78498", n)
+	}
+}
+
+func TestDivisors(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{1, []int{1}},
+		{12, []int{1, 2, 3, 4, 6, 12}},
+		{16, []int{1, 2, 4, 8, 16}},
+		{13, []int{1, 13}},
+	}
+	for _, tt := range tests {
+		if got := Divisors(tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("Divisors(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestIsqrt(t *testing.T) {
+	tests := []struct{ n, want int }{
+		{0, 0}, {1, 1}, {15, 3}, {16, 4}, {17, 4},
+		{1<<62 - 1, 1<<31 - 1},
+		{math.MaxInt, 3037000499},
+	}
+	for _, tt := range tests {
+		if got := Isqrt(tt.n); got != tt.want {
+			t.Errorf("Isqrt(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestMulChecked(t *testing.T) {
+	tests := []struct {
+		a, b, want int
+		ok         bool
+	}{
+		{3, 4, 12, true},
+		{-3, 4, -12, true},
+		{0, math.MaxInt, 0, true},
+		{1 << 31, 1 << 31, 1 << 62, true},
+		{1 << 32, 1 << 31, 0, false},
+		{-1, math.MinInt, 0, false},
+		{math.MinInt, -1, 0, false},
+		{math.MaxInt, 2, 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := MulChecked(tt.a, tt.b); got != tt.want || ok != tt.ok {
+			t.Errorf("MulChecked(%d, %d) = %d, %v, want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+}
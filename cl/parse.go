@@ -0,0 +1,328 @@
+package cl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ParseError reports where a line stopped matching its pattern.
+// Line and Col are 1-based, Line is 0 when parsing a single line.
+type ParseError struct {
+	Line    int
+	Col     int
+	Input   string
+	Pattern string
+	Msg     string
+	Err     error
+}
+
+func (e *ParseError) Error() string {
+	pos := fmt.Sprintf("col %d", e.Col)
+	if e.Line > 0 {
+		pos = fmt.Sprintf("line %d, %s", e.Line, pos)
+	}
+	return fmt.Sprintf("%s: %s in %q (pattern %q)", pos, e.Msg, e.Input, e.Pattern)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Pattern parses lines into T. A pattern is literal text with
+// captures in braces:
+//
+//	{name}        sets the field name, or the field tagged parse:"name"
+//	{pos.x}       sets field x of the field pos
+//	{name...sep}  splits the capture on sep into the slice field name
+//
+// Names are case-insensitive. A capture extends up to the next
+// literal text, or to the end of the line. Slices of structs are
+// parsed with the pattern in the field tag pattern:"...".
+type Pattern[T any] struct {
+	p *pattern
+}
+
+func NewPattern[T any](pattern string) (*Pattern[T], error) {
+	p, err := compilePattern(reflect.TypeFor[T](), pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &Pattern[T]{p}, nil
+}
+
+func MustPattern[T any](pattern string) *Pattern[T] {
+	p, err := NewPattern[T](pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p *Pattern[T]) Parse(line string) (T, error) {
+	var v T
+	err := p.p.parse(reflect.ValueOf(&v).Elem(), line, 0)
+	return v, err
+}
+
+func (p *Pattern[T]) MustParse(line string) T {
+	v, err := p.Parse(line)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ParseAll parses every line, errors carry the 1-based line number.
+func (p *Pattern[T]) ParseAll(lines []string) ([]T, error) {
+	out := make([]T, len(lines))
+	for i, line := range lines {
+		if err := p.p.parse(reflect.ValueOf(&out[i]).Elem(), line, 0); err != nil {
+			var perr *ParseError
+			if errors.As(err, &perr) {
+				perr.Line = i + 1
+			}
+			return out[:i], err
+		}
+	}
+	return out, nil
+}
+
+type patternKey struct {
+	typ     reflect.Type
+	pattern string
+}
+
+var patterns sync.Map
+
+// Parse parses line with a pattern that is compiled once per type.
+func Parse[T any](line, pattern string) (T, error) {
+	k := patternKey{reflect.TypeFor[T](), pattern}
+	if p, ok := patterns.Load(k); ok {
+		return p.(*Pattern[T]).Parse(line)
+	}
+	p, err := NewPattern[T](pattern)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	patterns.Store(k, p)
+	return p.Parse(line)
+}
+
+func MustParse[T any](line, pattern string) T {
+	v, err := Parse[T](line, pattern)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+type capture struct {
+	lit   string // literal text before the capture
+	name  string
+	field []int
+	sep   string
+	slice bool
+	elem  *pattern
+}
+
+type pattern struct {
+	src  string
+	caps []capture
+	tail string
+}
+
+func compilePattern(t reflect.Type, src string) (*pattern, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("pattern %q: %s is not a struct", src, t)
+	}
+	p := &pattern{src: src}
+	rest := src
+	for {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("pattern %q: unclosed {", src)
+		}
+		c := capture{lit: rest[:open], name: rest[open+1 : open+end]}
+		rest = rest[open+end+1:]
+		if len(p.caps) > 0 && c.lit == "" {
+			return nil, fmt.Errorf("pattern %q: {%s} must be separated from the previous capture", src, c.name)
+		}
+		if name, sep, ok := strings.Cut(c.name, "..."); ok {
+			c.name, c.sep, c.slice = name, sep, true
+			if sep == "" {
+				return nil, fmt.Errorf("pattern %q: {%s...} needs a separator", src, name)
+			}
+		}
+		f, idx, err := lookupField(t, c.name)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", src, err)
+		}
+		c.field = idx
+		ft := f.Type
+		if c.slice {
+			if ft.Kind() != reflect.Slice {
+				return nil, fmt.Errorf("pattern %q: field %s is not a slice", src, f.Name)
+			}
+			ft = ft.Elem()
+			if ft.Kind() == reflect.Struct {
+				sub, ok := f.Tag.Lookup("pattern")
+				if !ok {
+					return nil, fmt.Errorf("pattern %q: field %s needs a pattern tag", src, f.Name)
+				}
+				if c.elem, err = compilePattern(ft, sub); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if c.elem == nil && !scalar(ft.Kind()) {
+			return nil, fmt.Errorf("pattern %q: field %s has unsupported type %s", src, f.Name, ft)
+		}
+		p.caps = append(p.caps, c)
+	}
+	p.tail = rest
+	return p, nil
+}
+
+func lookupField(t reflect.Type, name string) (reflect.StructField, []int, error) {
+	var idx []int
+	var f reflect.StructField
+	for _, part := range strings.Split(name, ".") {
+		if t.Kind() != reflect.Struct {
+			return f, nil, fmt.Errorf("%s: %s is not a struct", name, t)
+		}
+		found := false
+		for i := range t.NumField() {
+			sf := t.Field(i)
+			tag, _, _ := strings.Cut(sf.Tag.Get("parse"), ",")
+			if tag == part || (tag == "" && strings.EqualFold(sf.Name, part)) {
+				if !sf.IsExported() {
+					return f, nil, fmt.Errorf("%s: field %s is not exported", name, sf.Name)
+				}
+				f, found = sf, true
+				idx = append(idx, i)
+				break
+			}
+		}
+		if !found {
+			return f, nil, fmt.Errorf("%s: no field %q in %s", name, part, t)
+		}
+		t = f.Type
+	}
+	return f, idx, nil
+}
+
+func scalar(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func (p *pattern) parse(v reflect.Value, line string, offset int) error {
+	fail := func(pos int, err error, msg string, args ...any) error {
+		return &ParseError{Col: offset + pos + 1, Input: line, Pattern: p.src, Msg: fmt.Sprintf(msg, args...), Err: err}
+	}
+	pos := 0
+	for i, c := range p.caps {
+		if !strings.HasPrefix(line[pos:], c.lit) {
+			return fail(pos, nil, "expected %q", c.lit)
+		}
+		pos += len(c.lit)
+		next := p.tail
+		if i+1 < len(p.caps) {
+			next = p.caps[i+1].lit
+		}
+		end := len(line)
+		if next != "" {
+			n := strings.Index(line[pos:], next)
+			if n < 0 {
+				return fail(pos, nil, "expected %q after {%s}", next, c.name)
+			}
+			end = pos + n
+		}
+		field := v.FieldByIndex(c.field)
+		if err := c.set(field, line[pos:end], offset+pos); err != nil {
+			var perr *ParseError
+			if errors.As(err, &perr) {
+				perr.Input, perr.Pattern = line, p.src
+				return perr
+			}
+			return fail(pos, err, "{%s}: %v", c.name, err)
+		}
+		pos = end
+	}
+	if line[pos:] != p.tail {
+		if !strings.HasPrefix(line[pos:], p.tail) {
+			return fail(pos, nil, "expected %q", p.tail)
+		}
+		return fail(pos+len(p.tail), nil, "unexpected trailing text")
+	}
+	return nil
+}
+
+func (c *capture) set(field reflect.Value, s string, offset int) error {
+	if !c.slice {
+		return setScalar(field, s)
+	}
+	parts := strings.Split(s, c.sep)
+	if strings.TrimSpace(c.sep) == "" {
+		parts = strings.Fields(s)
+	}
+	out := reflect.MakeSlice(field.Type(), len(parts), len(parts))
+	at := offset
+	for i, part := range parts {
+		if i > 0 {
+			at += strings.Index(s[at-offset:], part)
+		}
+		var err error
+		if c.elem != nil {
+			err = c.elem.parse(out.Index(i), part, at)
+		} else if err = setScalar(out.Index(i), part); err != nil {
+			err = &ParseError{Col: at + 1, Msg: fmt.Sprintf("{%s} element %d: %v", c.name, i+1, err), Err: err}
+		}
+		if err != nil {
+			return err
+		}
+		at += len(part)
+	}
+	field.Set(out)
+	return nil
+}
+
+func setScalar(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		v.SetBool(b)
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, v.Type().Bits())
+		v.SetInt(n)
+		return err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(s), 10, v.Type().Bits())
+		v.SetUint(n)
+		return err
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(s), v.Type().Bits())
+		v.SetFloat(n)
+		return err
+	}
+	return fmt.Errorf("unsupported type %s", v.Type())
+}
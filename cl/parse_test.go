@@ -0,0 +1,68 @@
+package cl
+
+import (
+	"errors"
+	"testing"
+)
+
+type parseList struct {
+	Name string
+	Xs   []int
+}
+
+type parsePoint struct {
+	X, Y int
+}
+
+type parsePath struct {
+	Name string
+	Pts  []parsePoint `pattern:"{x},{y}"`
+}
+
+func TestPatternCol(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func() error
+		col   int
+	}{
+		{"scalar", func() error {
+			_, err := Parse[parseList]("a: 1,2,x", "{name}: {xs...,}")
+			return err
+		}, 8},
+		{"scalar spaced", func() error {
+			_, err := Parse[parseList]("a: 10,  20,  x", "{name}: {xs...,}")
+			return err
+		}, 12},
+		{"nested", func() error {
+			_, err := Parse[parsePath]("p=1,2 3,4 5,x", "{name}={pts... }")
+			return err
+		}, 13},
+		{"nested literal", func() error {
+			_, err := Parse[parsePath]("p=1,2 3;4", "{name}={pts... }")
+			return err
+		}, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.parse()
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("got %v, want a *ParseError", err)
+			}
+			if perr.Col != tt.col {
+				t.Errorf("col %d, want %d: %v", perr.Col, tt.col, err)
+			}
+		})
+	}
+}
+
+func TestPatternParse(t *testing.T) {
+	got, err := Parse[parsePath]("p=1,2 3,4", "{name}={pts... }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []parsePoint{{1, 2}, {3, 4}}
+	if got.Name != "p" || len(got.Pts) != 2 || got.Pts[0] != want[0] || got.Pts[1] != want[1] {
+		t.Errorf("got %+v", got)
+	}
+}
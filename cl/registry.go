@@ -0,0 +1,243 @@
+package cl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	ExampleName = "Example"
+	PuzzleName  = "Puzzle"
+	PuzzleFile  = "puzzle.in"
+)
+
+// Case is a single expectation of a part. Stored cases take their
+// expected value from the day's answers file instead of Want.
+type Case struct {
+	Name   string
+	File   string
+	Want   any
+	Stored bool
+}
+
+type Part struct {
+	N      int
+	Cases  []Case
+	Fn     func(*Ctx, Input) any
+	decode func(json.RawMessage) (any, error)
+}
+
+type Day struct {
+	Year, Day int
+	Opts      InputOptions
+	Parts     []*Part
+}
+
+type Solver[T comparable] struct {
+	p *Part
+}
+
+type dayKey struct {
+	year, day int
+}
+
+var registry = make(map[dayKey]*Day)
+
+// NewDay registers a day. Every input file used by its parts is
+// found through DefaultInputs and parsed with opts.
+func NewDay(year, day int, opts InputOptions) *Day {
+	k := dayKey{year, day}
+	AssertM(registry[k] == nil, "%d day %d registered twice", year, day)
+	d := &Day{Year: year, Day: day, Opts: opts}
+	registry[k] = d
+	return d
+}
+
+func Solve[T comparable](d *Day, part int, fn func(Input) T) Solver[T] {
+	return SolveCtx(d, part, func(_ *Ctx, input Input) T { return fn(input) })
+}
+
+// SolveCtx is Solve for solvers that want a Ctx, to stop when their
+// case is out of time or to report progress.
+func SolveCtx[T comparable](d *Day, part int, fn func(*Ctx, Input) T) Solver[T] {
+	p := &Part{
+		N:  part,
+		Fn: func(c *Ctx, input Input) any { return fn(c, input) },
+		decode: func(raw json.RawMessage) (any, error) {
+			var v T
+			err := json.Unmarshal(raw, &v)
+			return v, err
+		},
+	}
+	d.Parts = append(d.Parts, p)
+	return Solver[T]{p}
+}
+
+func (s Solver[T]) Example(file string, want T) Solver[T] {
+	s.p.Cases = append(s.p.Cases, Case{Name: ExampleName, File: file, Want: want})
+	return s
+}
+
+// Puzzle adds a case for puzzle.in, checked against the answer
+// recorded for this part in answers.json.
+func (s Solver[T]) Puzzle() Solver[T] {
+	s.p.Cases = append(s.p.Cases, Case{Name: PuzzleName, File: PuzzleFile, Stored: true})
+	return s
+}
+
+func Lookup(year, day int) (*Day, bool) {
+	d, ok := registry[dayKey{year, day}]
+	return d, ok
+}
+
+func Days() []*Day {
+	days := make([]*Day, 0, len(registry))
+	for _, d := range registry {
+		days = append(days, d)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Year != days[j].Year {
+			return days[i].Year < days[j].Year
+		}
+		return days[i].Day < days[j].Day
+	})
+	return days
+}
+
+func (d *Day) String() string {
+	return fmt.Sprintf("%d/%02d", d.Year, d.Day)
+}
+
+// cases calls fn for every case with the given name, in part order.
+func (d *Day) cases(name string, fn func(p *Part, c Case)) {
+	for _, p := range d.Parts {
+		for _, c := range p.Cases {
+			if c.Name == name {
+				fn(p, c)
+			}
+		}
+	}
+}
+
+// files loads the inputs of a day at most once, together with its
+// answers.
+type files struct {
+	d          *Day
+	dir        string
+	cache      map[string]Input
+	answers    *Answers
+	answersErr error
+}
+
+func (d *Day) files(dir string) *files {
+	f := &files{d: d, dir: dir, cache: make(map[string]Input)}
+	f.answers, f.answersErr = LoadAnswers(dir)
+	return f
+}
+
+func (f *files) get(file string) (Input, error) {
+	if input, ok := f.cache[file]; ok {
+		return input, nil
+	}
+	input, err := DefaultInputs.Load(f.dir, f.d.Year, f.d.Day, file, f.d.Opts)
+	if err == nil {
+		f.cache[file] = input
+	}
+	return input, err
+}
+
+func (f *files) want(p *Part, c Case) (any, bool, error) {
+	if c.Stored && f.answersErr != nil {
+		return nil, false, f.answersErr
+	}
+	return p.want(c, f.answers)
+}
+
+// expect runs c into r under ctx and returns its result along with
+// the solver bound to the loaded input, which is nil if loading failed.
+func (d *Day) expect(ctx context.Context, r *Report, p *Part, c Case, f *files) (Result, func(*Ctx) any) {
+	res := Result{Year: d.Year, Day: d.Day, Name: c.Name, Part: p.N, File: c.File}
+	input, err := f.get(c.File)
+	if err != nil {
+		res.Want, res.Error = c.Want, err.Error()
+		r.Add(res)
+		return res, nil
+	}
+	fn := func(c *Ctx) any { return p.Fn(c, input) }
+	want, ok, err := f.want(p, c)
+	switch {
+	case err != nil:
+		res.Error = err.Error()
+		r.Add(res)
+		return res, fn
+	case !ok:
+		return unanswered(ctx, r, res, f.answers, fn), fn
+	}
+	return check(ctx, r, res, Ex[any]{Want: want, CtxFn: fn}), fn
+}
+
+// Run evaluates every example followed by every puzzle case into r,
+// reading each input file at most once, starting the search in dir.
+// Cases whose input cannot be loaded are reported as failed.
+func (d *Day) Run(r *Report, dir string) []Result {
+	return d.RunContext(context.Background(), r, dir, 0)
+}
+
+// RunContext is Run with every case given at most timeout, or no
+// limit if it is 0. A case that is still running when its time is up,
+// or when ctx is done, fails with an error and is left running in the
+// background while the next case starts.
+func (d *Day) RunContext(ctx context.Context, r *Report, dir string, timeout time.Duration) []Result {
+	var results []Result
+	f := d.files(dir)
+	for _, name := range []string{ExampleName, PuzzleName} {
+		d.cases(name, func(p *Part, c Case) {
+			ctx := ctx
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			res, _ := d.expect(ctx, r, p, c, f)
+			results = append(results, res)
+		})
+	}
+	return results
+}
+
+// Prepared is a case with its input loaded and its answer looked up.
+type Prepared struct {
+	Part *Part
+	Case Case
+	// Err is why the input or the answer could not be loaded.
+	Err error
+	// Want is the expected answer if Answered is set.
+	Want     any
+	Answered bool
+	// Fn runs the part on the loaded input, nil if loading failed.
+	Fn func(*Ctx) any
+}
+
+// Prepare loads every case, examples first, reading each input file
+// at most once and starting the search in dir. It lets other drivers,
+// such as go test, run the cases their own way.
+func (d *Day) Prepare(dir string) []Prepared {
+	var out []Prepared
+	f := d.files(dir)
+	for _, name := range []string{ExampleName, PuzzleName} {
+		d.cases(name, func(p *Part, c Case) {
+			pc := Prepared{Part: p, Case: c}
+			input, err := f.get(c.File)
+			if err == nil {
+				pc.Fn = func(c *Ctx) any { return p.Fn(c, input) }
+				pc.Want, pc.Answered, err = f.want(p, c)
+			}
+			pc.Err = err
+			out = append(out, pc)
+		})
+	}
+	return out
+}
@@ -0,0 +1,304 @@
+package cl
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+type Result struct {
+	Year     int           `json:"year,omitempty"`
+	Day      int           `json:"day,omitempty"`
+	Name     string        `json:"name"`
+	Part     int           `json:"part"`
+	File     string        `json:"file,omitempty"`
+	Got      any           `json:"got"`
+	Want     any           `json:"want"`
+	Duration time.Duration `json:"duration_ns"`
+	Pass     bool          `json:"pass"`
+	Panic    string        `json:"panic,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Note     string        `json:"note,omitempty"`
+	Skipped  bool          `json:"skipped,omitempty"`
+	Memo     *MemoStats    `json:"memo,omitempty"`
+}
+
+func (r Result) Label() string {
+	return fmt.Sprintf("%s %d", r.Name, r.Part)
+}
+
+func (r Result) Suite() string {
+	if r.Year == 0 {
+		return "aoc"
+	}
+	return fmt.Sprintf("%d/%02d", r.Year, r.Day)
+}
+
+func (r Result) String() string {
+	switch {
+	case r.Panic != "":
+		return fmt.Sprintf("%s panicked: %s", r.Label(), r.Panic)
+	case r.Error != "":
+		return fmt.Sprintf("%s error: %s", r.Label(), r.Error)
+	case !r.Pass && r.Note != "":
+		return fmt.Sprintf("%s failed: %s\nGot : %v", r.Label(), r.Note, r.Got)
+	case !r.Pass:
+		return fmt.Sprintf("%s failed\nGot : %v\nWant: %v", r.Label(), r.Got, r.Want)
+	}
+	s := fmt.Sprintf("%s: %v (%s)", r.Label(), r.Got, r.Duration.String())
+	if r.Note != "" {
+		s += fmt.Sprintf(" [%s]", r.Note)
+	}
+	if r.Memo != nil {
+		s += fmt.Sprintf(" memo: %s", r.Memo)
+	}
+	return s
+}
+
+// Report collects results. When Out is set every result is
+// also written to it as soon as it is added.
+type Report struct {
+	mu      sync.Mutex
+	Out     io.Writer
+	Results []Result
+}
+
+var DefaultReport = &Report{Out: os.Stdout}
+
+func (r *Report) Add(res Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Results = append(r.Results, res)
+	if r.Out != nil {
+		fmt.Fprintln(r.Out, res)
+	}
+}
+
+func (r *Report) Failed() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failed()
+}
+
+func (r *Report) failed() int {
+	n := 0
+	for _, res := range r.Results {
+		if !res.Pass {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *Report) Summary() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.summary()
+}
+
+func (r *Report) summary() string {
+	failed := r.failed()
+	return fmt.Sprintf("%d passed, %d failed", len(r.Results)-failed, failed)
+}
+
+// Sort orders the results by day, keeping the order of the results
+// of each day.
+func (r *Report) Sort() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sort.SliceStable(r.Results, func(i, j int) bool {
+		a, b := r.Results[i], r.Results[j]
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		return a.Day < b.Day
+	})
+}
+
+// WriteTable writes the results as a table with a row per case.
+func (r *Report) WriteTable(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tCASE\tSTATUS\tTIME\tANSWER")
+	for _, res := range r.Results {
+		var status, answer string
+		switch {
+		case res.Panic != "":
+			status, answer = "PANIC", res.Panic
+		case res.Error != "":
+			status, answer = "ERROR", res.Error
+		case !res.Pass && res.Note != "":
+			status, answer = "FAIL", fmt.Sprintf("%v (%s)", res.Got, res.Note)
+		case !res.Pass:
+			status, answer = "FAIL", fmt.Sprintf("%v, want %v", res.Got, res.Want)
+		case res.Note != "":
+			status, answer = "ok", fmt.Sprintf("%v (%s)", res.Got, res.Note)
+		default:
+			status, answer = "ok", fmt.Sprint(res.Got)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", res.Suite(), res.Label(), status, res.Duration.Round(time.Microsecond), answer)
+	}
+	return tw.Flush()
+}
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.Results)
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	Skipped   *junitFailure `xml:"skipped,omitempty"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     float64     `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+// WriteJUnit groups results into one testsuite per day, in the
+// order the days first appear in the report.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out junitSuites
+	idx := make(map[string]int)
+	for _, res := range r.Results {
+		name := res.Suite()
+		i, ok := idx[name]
+		if !ok {
+			i = len(out.Suites)
+			idx[name] = i
+			out.Suites = append(out.Suites, junitSuite{Name: name})
+		}
+		s := &out.Suites[i]
+		c := junitCase{Name: res.Label(), Classname: name, Time: res.Duration.Seconds()}
+		switch {
+		case res.Panic != "" || res.Error != "":
+			c.Error = &junitFailure{Message: res.Panic + res.Error, Body: res.String()}
+			s.Errors++
+		case !res.Pass:
+			c.Failure = &junitFailure{Message: "wrong answer", Body: res.String()}
+			s.Failures++
+		case res.Skipped:
+			c.Skipped = &junitFailure{Message: res.Note, Body: res.String()}
+			s.Skipped++
+		}
+		s.Tests++
+		s.Time += c.Time
+		s.Cases = append(s.Cases, c)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func check[T comparable](ctx context.Context, r *Report, res Result, expected Ex[T]) Result {
+	got, msg, err := timed(ctx, &res, expected.run)
+	res.Want = expected.Want
+	switch {
+	case err != nil:
+		res.Error = err.Error()
+	case msg != "":
+		res.Panic = msg
+	default:
+		res.Got = got
+		res.Pass = got == expected.Want
+	}
+	r.Add(res)
+	return res
+}
+
+// timed runs fn, recording its duration and the memo lookups it
+// made in res. If ctx is done first, timed stops waiting for fn and
+// returns an error at once. fn keeps running in the background until
+// it returns, so solvers that may run long should check their Ctx.
+func timed[T any](ctx context.Context, res *Result, fn func(*Ctx) T) (got T, msg string, err error) {
+	c := newCtx(ctx, res.Suite()+" "+res.Label())
+	if res.File != "" {
+		stem := strings.TrimSuffix(res.File, filepath.Ext(res.File))
+		c.name = fmt.Sprintf("%d-%02d-%s-%d", res.Year, res.Day, stem, res.Part)
+	}
+	defer c.finish()
+	start := time.Now()
+	call := func() T {
+		active.Add(1)
+		defer active.Add(-1)
+		return fn(c)
+	}
+	if ctx.Done() == nil {
+		got, msg = protect(call)
+	} else {
+		type result struct {
+			got T
+			msg string
+		}
+		done := make(chan result, 1)
+		go func() {
+			got, msg := protect(call)
+			done <- result{got, msg}
+		}()
+		select {
+		case r := <-done:
+			got, msg = r.got, r.msg
+		case <-ctx.Done():
+			res.Duration = time.Since(start)
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return got, "", fmt.Errorf("timed out after %s", res.Duration.Round(time.Millisecond))
+			}
+			return got, "", ctx.Err()
+		}
+	}
+	res.Duration = time.Since(start)
+	res.Memo = c.memoStats()
+	return got, msg, nil
+}
+
+func protect[T any](fn func() T) (v T, msg string) {
+	defer func() {
+		if p := recover(); p != nil {
+			msg = fmt.Sprint(p)
+		}
+	}()
+	return fn(), ""
+}
@@ -0,0 +1,152 @@
+package cl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCheckTimeout checks that a solver ignoring its Ctx does not hold
+// up the harness after its deadline.
+func TestCheckTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ex := Ex[int]{Want: 1, Fn: func() int {
+		<-block
+		return 0
+	}}
+	done := make(chan Result)
+	go func() {
+		done <- check(ctx, &Report{}, Result{Name: "t", Part: 1}, ex)
+	}()
+	select {
+	case res := <-done:
+		if !strings.HasPrefix(res.Error, "timed out") {
+			t.Errorf("error %q, want a timeout", res.Error)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("check waited for a solver past its deadline")
+	}
+}
+
+// TestRunContextTimeout checks that a day moves on to its next case
+// while a timed-out one is still running.
+func TestRunContextTimeout(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "example.in"), []byte("1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	block := make(chan struct{})
+	defer close(block)
+	d := &Day{Year: 1, Day: 1, Opts: Lines}
+	Solve(d, 1, func(Input) int {
+		<-block
+		return 0
+	}).Example("example.in", 1)
+	Solve(d, 2, func(Input) int { return 2 }).Example("example.in", 2)
+	done := make(chan []Result)
+	go func() {
+		done <- d.RunContext(context.Background(), &Report{}, dir, 10*time.Millisecond)
+	}()
+	select {
+	case results := <-done:
+		if len(results) != 2 || results[0].Error == "" || !results[1].Pass {
+			t.Errorf("got %+v, want a timeout then a pass", results)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("RunContext waited for a timed-out solver")
+	}
+}
+
+func TestCheckPanic(t *testing.T) {
+	res := check(context.Background(), &Report{}, Result{Name: "t", Part: 1}, Ex[int]{Fn: func() int { panic("boom") }})
+	if res.Pass || res.Panic != "boom" {
+		t.Errorf("got %+v, want a panic", res)
+	}
+}
+
+// sampleReport has a pass, a wrong answer, a panic, an error and a
+// skipped puzzle across two days, the days out of order.
+func sampleReport() *Report {
+	r := &Report{}
+	for _, res := range []Result{
+		{Year: 2024, Day: 2, Name: ExampleName, Part: 1, Got: 2, Want: 2, Pass: true},
+		{Year: 2024, Day: 1, Name: ExampleName, Part: 1, Got: 1, Want: 1, Pass: true},
+		{Year: 2024, Day: 1, Name: ExampleName, Part: 2, Got: 3, Want: 4},
+		{Year: 2024, Day: 1, Name: PuzzleName, Part: 1, Panic: "boom"},
+		{Year: 2024, Day: 2, Name: PuzzleName, Part: 1, Error: "timed out after 1s"},
+		{Year: 2024, Day: 2, Name: PuzzleName, Part: 2, Got: 5, Pass: true, Note: "no answer", Skipped: true},
+	} {
+		r.Add(res)
+	}
+	return r
+}
+
+func TestWriteJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := sampleReport().WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	var got []Result
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("%v in %s", err, b.String())
+	}
+	failed, skipped := 0, 0
+	for _, res := range got {
+		if !res.Pass {
+			failed++
+		}
+		if res.Skipped {
+			skipped++
+		}
+	}
+	if len(got) != 6 || failed != 3 || skipped != 1 {
+		t.Errorf("%d results, %d failed, %d skipped, want 6, 3 and 1", len(got), failed, skipped)
+	}
+	if r := got[3]; r.Suite() != "2024/01" || r.Label() != "Puzzle 1" || r.Panic != "boom" {
+		t.Errorf("got %+v", r)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var b bytes.Buffer
+	if err := sampleReport().WriteJUnit(&b); err != nil {
+		t.Fatal(err)
+	}
+	var got junitSuites
+	if err := xml.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("%v in %s", err, b.String())
+	}
+	want := []junitSuite{
+		{Name: "2024/02", Tests: 3, Errors: 1, Skipped: 1},
+		{Name: "2024/01", Tests: 3, Failures: 1, Errors: 1},
+	}
+	if len(got.Suites) != len(want) {
+		t.Fatalf("%d testsuites, want %d:\n%s", len(got.Suites), len(want), b.String())
+	}
+	for i, s := range got.Suites {
+		w := want[i]
+		if s.Name != w.Name || s.Tests != w.Tests || s.Failures != w.Failures || s.Errors != w.Errors || s.Skipped != w.Skipped || len(s.Cases) != s.Tests {
+			t.Errorf("testsuite %d: got %+v, want %+v", i, s, w)
+		}
+		for _, c := range s.Cases {
+			if c.Classname != s.Name {
+				t.Errorf("%s in testsuite %s", c.Classname, s.Name)
+			}
+		}
+	}
+	if c := got.Suites[0].Cases[2]; c.Name != "Puzzle 2" || c.Skipped == nil || c.Skipped.Message != "no answer" {
+		t.Errorf("got %+v, want a skipped Puzzle 2", c)
+	}
+	if c := got.Suites[1].Cases[1]; c.Name != "Example 2" || c.Failure == nil {
+		t.Errorf("got %+v, want a failed Example 2", c)
+	}
+}
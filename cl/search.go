@@ -0,0 +1,170 @@
+package cl
+
+// Edge leads to the state To at Cost, which must not be negative.
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// Search is the outcome of a graph search. Dist holds the distance
+// of every state that was reached and Prev all predecessors of a
+// state on a shortest path. Found is false when no goal state was
+// reached, otherwise Ends holds every goal state at the distance
+// Cost.
+type Search[S comparable] struct {
+	Start S
+	Dist  map[S]int
+	Prev  map[S][]S
+	Ends  []S
+	Cost  int
+	Found bool
+}
+
+func newSearch[S comparable](start S) *Search[S] {
+	return &Search[S]{
+		Start: start,
+		Dist:  map[S]int{start: 0},
+		Prev:  make(map[S][]S),
+	}
+}
+
+// relax records that to is reachable from s at d and reports whether
+// it is a new shortest distance.
+func (r *Search[S]) relax(s, to S, d int) bool {
+	old, ok := r.Dist[to]
+	switch {
+	case !ok || d < old:
+		r.Dist[to] = d
+		r.Prev[to] = append(r.Prev[to][:0], s)
+		return true
+	case d == old:
+		r.Prev[to] = append(r.Prev[to], s)
+	}
+	return false
+}
+
+// reached adds s to Ends if it is a goal at the distance of the
+// first goal found.
+func (r *Search[S]) reached(s S, d int, goal func(S) bool) {
+	if goal == nil || !goal(s) {
+		return
+	}
+	if !r.Found {
+		r.Found, r.Cost = true, d
+	}
+	if d == r.Cost {
+		r.Ends = append(r.Ends, s)
+	}
+}
+
+// BFS searches with unit edge costs. With a nil goal every reachable
+// state is visited.
+func BFS[S comparable](start S, next func(S) []S, goal func(S) bool) *Search[S] {
+	r := newSearch(start)
+	q := NewDeque[S]()
+	q.PushBack(start)
+	for !q.Empty() {
+		s, _ := q.PopFront()
+		d := r.Dist[s]
+		if r.Found && d > r.Cost {
+			break
+		}
+		r.reached(s, d, goal)
+		if r.Found {
+			continue
+		}
+		for _, to := range next(s) {
+			if r.relax(s, to, d+1) {
+				q.PushBack(to)
+			}
+		}
+	}
+	return r
+}
+
+// Dijkstra searches with weighted edges. With a nil goal every
+// reachable state is visited.
+func Dijkstra[S comparable](start S, next func(S) []Edge[S], goal func(S) bool) *Search[S] {
+	return AStar(start, next, goal, nil)
+}
+
+// AStar is Dijkstra guided by h, an estimate of the remaining cost
+// that must never overestimate and should be consistent for Prev to
+// hold every shortest path. A nil h makes it Dijkstra.
+func AStar[S comparable](start S, next func(S) []Edge[S], goal func(S) bool, h func(S) int) *Search[S] {
+	type item struct {
+		s    S
+		d, f int
+	}
+	r := newSearch(start)
+	estimate := func(s S, d int) int {
+		if h == nil {
+			return d
+		}
+		return d + h(s)
+	}
+	q := NewPrio(func(a, b item) bool { return a.f < b.f })
+	q.Add(item{start, 0, estimate(start, 0)})
+	for !q.Empty() {
+		it := q.Next()
+		if it.d != r.Dist[it.s] {
+			continue
+		}
+		if r.Found && it.f > r.Cost {
+			break
+		}
+		r.reached(it.s, it.d, goal)
+		if r.Found {
+			continue
+		}
+		for _, e := range next(it.s) {
+			d := it.d + e.Cost
+			if r.relax(it.s, e.To, d) {
+				q.Add(item{e.To, d, estimate(e.To, d)})
+			}
+		}
+	}
+	return r
+}
+
+// Path returns one shortest path from Start to the first end, both
+// included, or nil if no goal was found.
+func (r *Search[S]) Path() []S {
+	if !r.Found {
+		return nil
+	}
+	return r.PathTo(r.Ends[0])
+}
+
+// PathTo returns one shortest path from Start to s, or nil if s was
+// not reached.
+func (r *Search[S]) PathTo(s S) []S {
+	if _, ok := r.Dist[s]; !ok {
+		return nil
+	}
+	path := []S{s}
+	for s != r.Start {
+		s = r.Prev[s][0]
+		path = append(path, s)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// OnPath returns every state on any shortest path to any of the ends.
+func (r *Search[S]) OnPath() Set[S] {
+	seen := NewSet[S]()
+	stack := append([]S(nil), r.Ends...)
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen.Has(s) {
+			continue
+		}
+		seen.Add(s)
+		stack = append(stack, r.Prev[s]...)
+	}
+	return seen
+}
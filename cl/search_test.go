@@ -0,0 +1,173 @@
+package cl
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// maze parses rows into a grid with its S and E positions.
+func maze(rows ...string) (g Grid[byte], start, end Vec2) {
+	b := make([][]byte, len(rows))
+	for i, r := range rows {
+		b[i] = []byte(r)
+	}
+	g = GridOf(b)
+	for v, c := range g.All() {
+		switch c {
+		case 'S':
+			start = v
+		case 'E':
+			end = v
+		}
+	}
+	return g, start, end
+}
+
+func mazeNext(g Grid[byte]) func(Vec2) []Vec2 {
+	return func(v Vec2) []Vec2 {
+		var out []Vec2
+		for _, n := range v.Neighbors4() {
+			if c, ok := g.Get(n); ok && c != '#' {
+				out = append(out, n)
+			}
+		}
+		return out
+	}
+}
+
+func mazeEdges(g Grid[byte]) func(Vec2) []Edge[Vec2] {
+	next := mazeNext(g)
+	return func(v Vec2) []Edge[Vec2] {
+		var out []Edge[Vec2]
+		for _, n := range next(v) {
+			out = append(out, Edge[Vec2]{n, cellCost(g.At(n))})
+		}
+		return out
+	}
+}
+
+// cellCost is the cost of entering a cell, its digit or 1.
+func cellCost(c byte) int {
+	if c >= '1' && c <= '9' {
+		return int(c - '0')
+	}
+	return 1
+}
+
+// checkPath fails t unless path is a walk of unit steps from start to
+// end along open cells.
+func checkPath(t *testing.T, g Grid[byte], path []Vec2, start, end Vec2) {
+	t.Helper()
+	if len(path) == 0 || path[0] != start || path[len(path)-1] != end {
+		t.Fatalf("path %v does not lead from %v to %v", path, start, end)
+	}
+	for i := 1; i < len(path); i++ {
+		if path[i].Manhattan(path[i-1]) != 1 || g.At(path[i]) == '#' {
+			t.Fatalf("invalid step %v -> %v", path[i-1], path[i])
+		}
+	}
+}
+
+func TestBFS(t *testing.T) {
+	g, start, end := maze(
+		"S..#....",
+		".#.#.##.",
+		".#...#..",
+		".####.#.",
+		"......#E",
+	)
+	r := BFS(start, mazeNext(g), func(v Vec2) bool { return v == end })
+	if !r.Found || r.Cost != 15 {
+		t.Fatalf("found %v at %d, want 15", r.Found, r.Cost)
+	}
+	path := r.Path()
+	checkPath(t, g, path, start, end)
+	if len(path) != r.Cost+1 {
+		t.Errorf("path of %d states for cost %d", len(path), r.Cost)
+	}
+	if r.PathTo(V2(4, 3)) != nil {
+		t.Error("path to a wall")
+	}
+}
+
+func TestOnPath(t *testing.T) {
+	// two shortest paths around the wall in the middle
+	g, start, end := maze(
+		"S..",
+		".#.",
+		"..E",
+	)
+	r := BFS(start, mazeNext(g), func(v Vec2) bool { return v == end })
+	if on := r.OnPath(); on.Len() != 8 || on.Has(V2(1, 1)) {
+		t.Errorf("OnPath has %d states", on.Len())
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	g, start, end := maze(
+		"S#.",
+		"##E",
+	)
+	goal := func(v Vec2) bool { return v == end }
+	for name, r := range map[string]*Search[Vec2]{
+		"BFS":      BFS(start, mazeNext(g), goal),
+		"Dijkstra": Dijkstra(start, mazeEdges(g), goal),
+	} {
+		if r.Found || r.Path() != nil || len(r.Dist) != 1 {
+			t.Errorf("%s: found %v, reached %d", name, r.Found, len(r.Dist))
+		}
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	// the short way through the 9s costs more than the detour
+	g, start, end := maze(
+		"S99E",
+		".##.",
+		"....",
+	)
+	r := Dijkstra(start, mazeEdges(g), func(v Vec2) bool { return v == end })
+	if !r.Found || r.Cost != 7 {
+		t.Fatalf("found %v at %d, want 7", r.Found, r.Cost)
+	}
+	path := r.Path()
+	checkPath(t, g, path, start, end)
+	cost := 0
+	for _, v := range path[1:] {
+		cost += cellCost(g.At(v))
+	}
+	if cost != r.Cost {
+		t.Errorf("path %v costs %d, want %d", path, cost, r.Cost)
+	}
+}
+
+// TestAStar compares AStar with a Manhattan heuristic to Dijkstra on
+// random weighted mazes.
+func TestAStar(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	for range 50 {
+		g := NewGrid[byte](12, 9)
+		for i := range g.Cells {
+			switch n := rng.IntN(10); {
+			case n < 2:
+				g.Cells[i] = '#'
+			case n < 5:
+				g.Cells[i] = byte('1' + rng.IntN(9))
+			default:
+				g.Cells[i] = '.'
+			}
+		}
+		start, end := V2(0, 0), V2(g.W-1, g.H-1)
+		g.Set(start, '.')
+		g.Set(end, '.')
+		goal := func(v Vec2) bool { return v == end }
+		want := Dijkstra(start, mazeEdges(g), goal)
+		got := AStar(start, mazeEdges(g), goal, func(v Vec2) int { return v.Manhattan(end) })
+		if got.Found != want.Found || got.Cost != want.Cost {
+			t.Fatalf("AStar found %v at %d, Dijkstra %v at %d\n%s", got.Found, got.Cost, want.Found, want.Cost, g)
+		}
+		if got.Found {
+			checkPath(t, g, got.Path(), start, end)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package cl
+
+import (
+	"iter"
+	"math/bits"
+	"sort"
+)
+
+type Set[T comparable] map[T]struct{}
+
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	s.Add(items...)
+	return s
+}
+
+func (s Set[T]) Add(items ...T) {
+	for _, k := range items {
+		s[k] = struct{}{}
+	}
+}
+
+func (s Set[T]) Has(k T) bool {
+	_, ok := s[k]
+	return ok
+}
+
+func (s Set[T]) Remove(k T) {
+	delete(s, k)
+}
+
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range s {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Pop removes and returns an arbitrary element, ok is false if s is
+// empty.
+func (s Set[T]) Pop() (k T, ok bool) {
+	for k = range s {
+		delete(s, k)
+		return k, true
+	}
+	return k, false
+}
+
+func (s Set[T]) Clone() Set[T] {
+	c := make(Set[T], len(s))
+	for k := range s {
+		c[k] = struct{}{}
+	}
+	return c
+}
+
+func (s Set[T]) Union(o Set[T]) Set[T] {
+	u := s.Clone()
+	for k := range o {
+		u[k] = struct{}{}
+	}
+	return u
+}
+
+func (s Set[T]) Intersect(o Set[T]) Set[T] {
+	if len(o) < len(s) {
+		s, o = o, s
+	}
+	out := make(Set[T])
+	for k := range s {
+		if o.Has(k) {
+			out[k] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Difference returns the elements of s that are not in o.
+func (s Set[T]) Difference(o Set[T]) Set[T] {
+	out := make(Set[T])
+	for k := range s {
+		if !o.Has(k) {
+			out[k] = struct{}{}
+		}
+	}
+	return out
+}
+
+// SymmetricDifference returns the elements in exactly one of s and o.
+func (s Set[T]) SymmetricDifference(o Set[T]) Set[T] {
+	out := s.Difference(o)
+	for k := range o {
+		if !s.Has(k) {
+			out[k] = struct{}{}
+		}
+	}
+	return out
+}
+
+// IsSubset reports whether every element of s is in o.
+func (s Set[T]) IsSubset(o Set[T]) bool {
+	if len(s) > len(o) {
+		return false
+	}
+	for k := range s {
+		if !o.Has(k) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Set[T]) Sorted(less func(a T, b T) bool) []T {
+	keys := make([]T, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return less(keys[i], keys[j])
+	})
+	return keys
+}
+
+// BitSet is a set of small non-negative ints, one bit each.
+type BitSet struct {
+	words []uint64
+}
+
+func NewBitSet(n int) *BitSet {
+	return &BitSet{words: make([]uint64, (n+63)/64)}
+}
+
+func (b *BitSet) Add(i int) {
+	AssertM(i >= 0, "negative bit %d", i)
+	w := i / 64
+	for w >= len(b.words) {
+		b.words = append(b.words, 0)
+	}
+	b.words[w] |= 1 << (i % 64)
+}
+
+func (b *BitSet) Has(i int) bool {
+	w := i / 64
+	return i >= 0 && w < len(b.words) && b.words[w]&(1<<(i%64)) != 0
+}
+
+func (b *BitSet) Remove(i int) {
+	if w := i / 64; i >= 0 && w < len(b.words) {
+		b.words[w] &^= 1 << (i % 64)
+	}
+}
+
+func (b *BitSet) Len() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// All yields the elements in increasing order.
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range b.words {
+			for w != 0 {
+				t := bits.TrailingZeros64(w)
+				if !yield(i*64 + t) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// Pop removes and returns the smallest element, ok is false if b is
+// empty.
+func (b *BitSet) Pop() (int, bool) {
+	for i, w := range b.words {
+		if w != 0 {
+			t := bits.TrailingZeros64(w)
+			b.words[i] &^= 1 << t
+			return i*64 + t, true
+		}
+	}
+	return 0, false
+}
+
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// combine applies op to the words of b and o, the shorter one padded
+// with zeros.
+func (b *BitSet) combine(o *BitSet, op func(x, y uint64) uint64) *BitSet {
+	out := &BitSet{words: make([]uint64, max(len(b.words), len(o.words)))}
+	for i := range out.words {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(o.words) {
+			y = o.words[i]
+		}
+		out.words[i] = op(x, y)
+	}
+	return out
+}
+
+func (b *BitSet) Union(o *BitSet) *BitSet {
+	return b.combine(o, func(x, y uint64) uint64 { return x | y })
+}
+
+func (b *BitSet) Intersect(o *BitSet) *BitSet {
+	return b.combine(o, func(x, y uint64) uint64 { return x & y })
+}
+
+func (b *BitSet) Difference(o *BitSet) *BitSet {
+	return b.combine(o, func(x, y uint64) uint64 { return x &^ y })
+}
+
+func (b *BitSet) SymmetricDifference(o *BitSet) *BitSet {
+	return b.combine(o, func(x, y uint64) uint64 { return x ^ y })
+}
+
+func (b *BitSet) IsSubset(o *BitSet) bool {
+	return b.Difference(o).Len() == 0
+}
+
+// GridSet is a BitSet of the positions of a w by h grid.
+type GridSet struct {
+	W, H int
+	bits *BitSet
+}
+
+func NewGridSet(w, h int) *GridSet {
+	return &GridSet{W: w, H: h, bits: NewBitSet(w * h)}
+}
+
+func (g *GridSet) in(v Vec2) bool {
+	return v.X >= 0 && v.X < g.W && v.Y >= 0 && v.Y < g.H
+}
+
+// Add adds v and reports whether it is inside the grid.
+func (g *GridSet) Add(v Vec2) bool {
+	if !g.in(v) {
+		return false
+	}
+	g.bits.Add(v.Y*g.W + v.X)
+	return true
+}
+
+func (g *GridSet) Has(v Vec2) bool {
+	return g.in(v) && g.bits.Has(v.Y*g.W+v.X)
+}
+
+func (g *GridSet) Remove(v Vec2) {
+	if g.in(v) {
+		g.bits.Remove(v.Y*g.W + v.X)
+	}
+}
+
+func (g *GridSet) Len() int {
+	return g.bits.Len()
+}
+
+// All yields the positions in row order.
+func (g *GridSet) All() iter.Seq[Vec2] {
+	return func(yield func(Vec2) bool) {
+		for i := range g.bits.All() {
+			if !yield(Vec2{i % g.W, i / g.W}) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package cl
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func sorted(s Set[int]) []int {
+	return s.Sorted(func(a, b int) bool { return a < b })
+}
+
+func TestSetOps(t *testing.T) {
+	a, b := NewSet(1, 2, 3, 4), NewSet(3, 4, 5)
+	tests := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{"union", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"intersect", a.Intersect(b), []int{3, 4}},
+		{"difference", a.Difference(b), []int{1, 2}},
+		{"symmetric difference", a.SymmetricDifference(b), []int{1, 2, 5}},
+		{"empty", a.Intersect(NewSet[int]()), []int{}},
+	}
+	for _, tt := range tests {
+		if got := sorted(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := sorted(a); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("operands changed: %v", got)
+	}
+	if !NewSet(3, 4).IsSubset(a) || b.IsSubset(a) || !NewSet[int]().IsSubset(a) {
+		t.Error("IsSubset")
+	}
+}
+
+func TestSetPop(t *testing.T) {
+	s := NewSet(1, 2, 3)
+	c := s.Clone()
+	seen := NewSet[int]()
+	for {
+		k, ok := s.Pop()
+		if !ok {
+			break
+		}
+		if !c.Has(k) || seen.Has(k) {
+			t.Fatalf("popped %d", k)
+		}
+		seen.Add(k)
+	}
+	if s.Len() != 0 || seen.Len() != 3 || c.Len() != 3 {
+		t.Errorf("after popping: %v, seen %v, clone %v", s, seen, c)
+	}
+}
+
+// TestBitSet checks BitSet against a Set, with sets of different
+// word lengths so combine pads the shorter one.
+func TestBitSet(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		var sets [2]Set[int]
+		var bits [2]*BitSet
+		for i := range sets {
+			n := 1 + r.IntN(200)
+			sets[i], bits[i] = NewSet[int](), NewBitSet(r.IntN(64))
+			for range r.IntN(40) {
+				v := r.IntN(n)
+				sets[i].Add(v)
+				bits[i].Add(v)
+			}
+			v := r.IntN(n)
+			sets[i].Remove(v)
+			bits[i].Remove(v)
+		}
+		a, b := sets[0], sets[1]
+		x, y := bits[0], bits[1]
+		tests := []struct {
+			name string
+			got  *BitSet
+			want Set[int]
+		}{
+			{"set", x, a},
+			{"union", x.Union(y), a.Union(b)},
+			{"intersect", x.Intersect(y), a.Intersect(b)},
+			{"difference", x.Difference(y), a.Difference(b)},
+			{"symmetric difference", x.SymmetricDifference(y), a.SymmetricDifference(b)},
+			{"reverse difference", y.Difference(x), b.Difference(a)},
+		}
+		for _, tt := range tests {
+			got := slices.Collect(tt.got.All())
+			if want := sorted(tt.want); !slices.Equal(got, want) {
+				t.Fatalf("%s: got %v, want %v", tt.name, got, want)
+			}
+			if tt.got.Len() != tt.want.Len() {
+				t.Fatalf("%s: Len %d, want %d", tt.name, tt.got.Len(), tt.want.Len())
+			}
+		}
+		if x.IsSubset(y) != a.IsSubset(b) || !x.Intersect(y).IsSubset(y) {
+			t.Fatalf("IsSubset of %v and %v", a, b)
+		}
+	}
+}
+
+func TestBitSetPop(t *testing.T) {
+	b := NewBitSet(0)
+	want := []int{3, 64, 65, 200}
+	for _, v := range []int{200, 3, 65, 64} {
+		b.Add(v)
+	}
+	c := b.Clone()
+	var got []int
+	for {
+		v, ok := b.Pop()
+		if !ok {
+			break
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+	if c.Len() != len(want) || b.Has(-1) || b.Has(1000) {
+		t.Errorf("clone %v", slices.Collect(c.All()))
+	}
+	if msg := catchMsg(func() { b.Add(-1) }); msg == "" {
+		t.Error("Add(-1) did not panic")
+	}
+}
+
+func TestGridSet(t *testing.T) {
+	g := NewGridSet(3, 2)
+	tests := []struct {
+		v  Vec2
+		in bool
+	}{
+		{Vec2{2, 1}, true},
+		{Vec2{0, 0}, true},
+		{Vec2{1, 1}, true},
+		{Vec2{3, 0}, false},
+		{Vec2{0, 2}, false},
+		{Vec2{-1, 0}, false},
+	}
+	for _, tt := range tests {
+		if g.Add(tt.v) != tt.in || g.Has(tt.v) != tt.in {
+			t.Errorf("%v: want in %v", tt.v, tt.in)
+		}
+	}
+	want := []Vec2{{0, 0}, {1, 1}, {2, 1}}
+	if got := slices.Collect(g.All()); !slices.Equal(got, want) {
+		t.Errorf("All: got %v, want %v", got, want)
+	}
+	g.Remove(Vec2{1, 1})
+	g.Remove(Vec2{5, 5})
+	if g.Len() != 2 || g.Has(Vec2{1, 1}) {
+		t.Errorf("after Remove: %v", slices.Collect(g.All()))
+	}
+}
@@ -0,0 +1,179 @@
+package cl
+
+import (
+	"iter"
+	"strings"
+)
+
+// SparseGrid stores cells by position without bounds, negative
+// coordinates included. A toroidal grid, see NewTorus, wraps every
+// position into its size instead.
+type SparseGrid[T any] struct {
+	Cells    map[Vec2]T
+	Size     Vec2
+	min, max Vec2
+	dirty    bool
+}
+
+func NewSparseGrid[T any]() *SparseGrid[T] {
+	return &SparseGrid[T]{Cells: make(map[Vec2]T)}
+}
+
+// NewTorus returns a grid of w by h cells whose positions wrap
+// around at the edges, so Set(V2(-1, 0), x) stores x at (w-1, 0).
+func NewTorus[T any](w, h int) *SparseGrid[T] {
+	AssertM(w > 0 && h > 0, "torus of size %dx%d", w, h)
+	g := NewSparseGrid[T]()
+	g.Size = Vec2{w, h}
+	return g
+}
+
+func (g *SparseGrid[T]) wrap(v Vec2) Vec2 {
+	if g.Size == (Vec2{}) {
+		return v
+	}
+	return v.Mod(g.Size)
+}
+
+// Add moves v by d, wrapping around if the grid is toroidal.
+func (g *SparseGrid[T]) Add(v, d Vec2) Vec2 {
+	return g.wrap(v.Add(d))
+}
+
+func (g *SparseGrid[T]) Set(v Vec2, x T) {
+	v = g.wrap(v)
+	if len(g.Cells) == 0 && !g.dirty {
+		g.min, g.max = v, v
+	}
+	g.min = Vec2{min(g.min.X, v.X), min(g.min.Y, v.Y)}
+	g.max = Vec2{max(g.max.X, v.X), max(g.max.Y, v.Y)}
+	g.Cells[v] = x
+}
+
+func (g *SparseGrid[T]) Get(v Vec2) (T, bool) {
+	x, ok := g.Cells[g.wrap(v)]
+	return x, ok
+}
+
+// At returns the cell at v, or the zero value if it was never set.
+func (g *SparseGrid[T]) At(v Vec2) T {
+	return g.Cells[g.wrap(v)]
+}
+
+func (g *SparseGrid[T]) Has(v Vec2) bool {
+	_, ok := g.Cells[g.wrap(v)]
+	return ok
+}
+
+func (g *SparseGrid[T]) Delete(v Vec2) {
+	delete(g.Cells, g.wrap(v))
+	g.dirty = true
+}
+
+func (g *SparseGrid[T]) Len() int {
+	return len(g.Cells)
+}
+
+func (g *SparseGrid[T]) All() iter.Seq2[Vec2, T] {
+	return func(yield func(Vec2, T) bool) {
+		for v, x := range g.Cells {
+			if !yield(v, x) {
+				return
+			}
+		}
+	}
+}
+
+func (g *SparseGrid[T]) Keys() iter.Seq[Vec2] {
+	return func(yield func(Vec2) bool) {
+		for v := range g.Cells {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (g *SparseGrid[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, x := range g.Cells {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Bounds returns the smallest box holding every cell, both corners
+// inclusive. A toroidal grid always spans its full size.
+func (g *SparseGrid[T]) Bounds() (lo, hi Vec2) {
+	if g.Size != (Vec2{}) {
+		return Vec2{}, g.Size.Sub(Vec2{1, 1})
+	}
+	if g.dirty {
+		g.dirty = false
+		first := true
+		for v := range g.Cells {
+			if first {
+				g.min, g.max, first = v, v, false
+			}
+			g.min = Vec2{min(g.min.X, v.X), min(g.min.Y, v.Y)}
+			g.max = Vec2{max(g.max.X, v.X), max(g.max.Y, v.Y)}
+		}
+		if first {
+			g.min, g.max = Vec2{}, Vec2{}
+		}
+	}
+	return g.min, g.max
+}
+
+// Dense copies the bounding box into a Grid, cell (0, 0) of which
+// is at origin.
+func (g *SparseGrid[T]) Dense() (dense Grid[T], origin Vec2) {
+	if len(g.Cells) == 0 && g.Size == (Vec2{}) {
+		return Grid[T]{}, Vec2{}
+	}
+	lo, hi := g.Bounds()
+	dense = NewGrid[T](hi.X-lo.X+1, hi.Y-lo.Y+1)
+	for v, x := range g.Cells {
+		dense.Set(v.Sub(lo), x)
+	}
+	return dense, lo
+}
+
+// Render draws the bounding box row by row, top to bottom, with fn
+// choosing the character of every position.
+func (g *SparseGrid[T]) Render(fn func(x T, ok bool) rune) string {
+	if len(g.Cells) == 0 && g.Size == (Vec2{}) {
+		return ""
+	}
+	lo, hi := g.Bounds()
+	var sb strings.Builder
+	for y := lo.Y; y <= hi.Y; y++ {
+		if y > lo.Y {
+			sb.WriteByte('\n')
+		}
+		for x := lo.X; x <= hi.X; x++ {
+			c, ok := g.Cells[Vec2{x, y}]
+			sb.WriteRune(fn(c, ok))
+		}
+	}
+	return sb.String()
+}
+
+// String renders bytes and runes as they are, other set cells as #
+// and unset cells as '.'.
+func (g *SparseGrid[T]) String() string {
+	return g.Render(func(x T, ok bool) rune {
+		if !ok {
+			return '.'
+		}
+		switch x := any(x).(type) {
+		case byte:
+			return rune(x)
+		case rune:
+			return x
+		}
+		return '#'
+	})
+}
@@ -0,0 +1,54 @@
+package cl
+
+import "testing"
+
+func TestSparseGridBounds(t *testing.T) {
+	g := NewSparseGrid[byte]()
+	if lo, hi := g.Bounds(); lo != (Vec2{}) || hi != (Vec2{}) || g.String() != "" {
+		t.Errorf("empty grid: %v %v %q", lo, hi, g.String())
+	}
+	g.Set(V2(-2, 1), 'a')
+	g.Set(V2(1, -1), 'b')
+	g.Set(V2(0, 0), 'c')
+	if lo, hi := g.Bounds(); lo != V2(-2, -1) || hi != V2(1, 1) {
+		t.Errorf("bounds %v %v", lo, hi)
+	}
+	if want := "...b\n..c.\na..."; g.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", g, want)
+	}
+	g.Delete(V2(-2, 1))
+	if lo, hi := g.Bounds(); lo != V2(0, -1) || hi != V2(1, 0) {
+		t.Errorf("bounds after delete %v %v", lo, hi)
+	}
+	dense, origin := g.Dense()
+	if origin != V2(0, -1) || dense.W != 2 || dense.H != 2 || dense.At(V2(1, 0)) != 'b' || dense.At(V2(0, 1)) != 'c' {
+		t.Errorf("dense %+v at %v", dense, origin)
+	}
+	g.Delete(V2(1, -1))
+	g.Delete(V2(0, 0))
+	g.Set(V2(5, 5), 'd')
+	if lo, hi := g.Bounds(); lo != V2(5, 5) || hi != V2(5, 5) {
+		t.Errorf("bounds after emptying %v %v", lo, hi)
+	}
+}
+
+func TestTorus(t *testing.T) {
+	g := NewTorus[int](4, 3)
+	g.Set(V2(-1, 0), 1)
+	g.Set(V2(4, 4), 2)
+	tests := []struct {
+		v    Vec2
+		want int
+	}{{V2(3, 0), 1}, {V2(-5, 3), 1}, {V2(0, 1), 2}, {V2(8, -2), 2}, {V2(1, 1), 0}}
+	for _, tt := range tests {
+		if got := g.At(tt.v); got != tt.want {
+			t.Errorf("At(%v) = %d, want %d", tt.v, got, tt.want)
+		}
+	}
+	if v := g.Add(V2(3, 2), V2(1, 1)); v != V2(0, 0) {
+		t.Errorf("Add wraps to %v", v)
+	}
+	if lo, hi := g.Bounds(); lo != V2(0, 0) || hi != V2(3, 2) || g.Len() != 2 {
+		t.Errorf("bounds %v %v len %d", lo, hi, g.Len())
+	}
+}
@@ -0,0 +1,268 @@
+// Package term draws grids in a terminal with ANSI colors, redrawing
+// them in place to animate a simulation.
+package term
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/lindeneg/aoc/cl"
+)
+
+// Style is a list of ANSI SGR parameters such as "1;31", empty for
+// the default look.
+type Style string
+
+const (
+	Plain   Style = ""
+	Bold    Style = "1"
+	Dim     Style = "2"
+	Red     Style = "31"
+	Green   Style = "32"
+	Yellow  Style = "33"
+	Blue    Style = "34"
+	Magenta Style = "35"
+	Cyan    Style = "36"
+	Gray    Style = "90"
+)
+
+// FG is one of the 256 terminal colors as foreground.
+func FG(n uint8) Style {
+	return Style(fmt.Sprintf("38;5;%d", n))
+}
+
+// BG is one of the 256 terminal colors as background.
+func BG(n uint8) Style {
+	return Style(fmt.Sprintf("48;5;%d", n))
+}
+
+// RGB is a true color foreground.
+func RGB(r, g, b uint8) Style {
+	return Style(fmt.Sprintf("38;2;%d;%d;%d", r, g, b))
+}
+
+// With combines s and o, o winning where they conflict.
+func (s Style) With(o Style) Style {
+	switch {
+	case s == "":
+		return o
+	case o == "":
+		return s
+	}
+	return s + ";" + o
+}
+
+// Cell is a character drawn with a style.
+type Cell struct {
+	Ch    rune
+	Style Style
+}
+
+// Canvas is a grid of styled characters, made from a cl.Grid and then
+// decorated before it is drawn.
+type Canvas struct {
+	g cl.Grid[Cell]
+	// Origin is the position in the source grid of the top left cell,
+	// which moves when the canvas is cropped.
+	Origin cl.Vec2
+}
+
+// NewCanvas draws every cell of g as the character fn returns.
+func NewCanvas[T any](g cl.Grid[T], fn func(T) rune) *Canvas {
+	c := &Canvas{g: cl.NewGrid[Cell](g.W, g.H)}
+	for i, x := range g.Cells {
+		c.g.Cells[i] = Cell{Ch: fn(x)}
+	}
+	return c
+}
+
+// Bytes draws a grid of bytes as it is.
+func Bytes(g cl.Grid[byte]) *Canvas {
+	return NewCanvas(g, func(b byte) rune { return rune(b) })
+}
+
+// Rows draws rows such as those of a cl.B2, cl.I2 or cl.R2, as many
+// columns wide as the first one.
+func Rows[T any](rows [][]T, fn func(T) rune) *Canvas {
+	c := &Canvas{}
+	if len(rows) == 0 {
+		return c
+	}
+	c.g = cl.NewGrid[Cell](len(rows[0]), len(rows))
+	for y, row := range rows {
+		for x, v := range row[:min(len(row), c.g.W)] {
+			c.g.Cells[y*c.g.W+x] = Cell{Ch: fn(v)}
+		}
+	}
+	return c
+}
+
+// cell returns the cell at v, a position in the source grid, or nil
+// if it is outside of c.
+func (c *Canvas) cell(v cl.Vec2) *Cell {
+	v = v.Sub(c.Origin)
+	if !c.g.In(v) {
+		return nil
+	}
+	return &c.g.Cells[v.Y*c.g.W+v.X]
+}
+
+// Style adds s to the cell at v.
+func (c *Canvas) Style(v cl.Vec2, s Style) *Canvas {
+	if p := c.cell(v); p != nil {
+		p.Style = p.Style.With(s)
+	}
+	return c
+}
+
+// Class adds s to every cell showing ch.
+func (c *Canvas) Class(ch rune, s Style) *Canvas {
+	for i, cell := range c.g.Cells {
+		if cell.Ch == ch {
+			c.g.Cells[i].Style = cell.Style.With(s)
+		}
+	}
+	return c
+}
+
+// Path marks every position of path with s and, unless ch is 0,
+// draws it as ch.
+func (c *Canvas) Path(path []cl.Vec2, ch rune, s Style) *Canvas {
+	for _, v := range path {
+		if p := c.cell(v); p != nil {
+			if ch != 0 {
+				p.Ch = ch
+			}
+			p.Style = p.Style.With(s)
+		}
+	}
+	return c
+}
+
+// Crop returns the part of c between lo and hi, both inclusive and
+// positions of the source grid, clamped to c.
+func (c *Canvas) Crop(lo, hi cl.Vec2) *Canvas {
+	lo, hi = lo.Sub(c.Origin), hi.Sub(c.Origin)
+	lo = cl.V2(max(lo.X, 0), max(lo.Y, 0))
+	hi = cl.V2(min(hi.X, c.g.W-1), min(hi.Y, c.g.H-1))
+	out := &Canvas{Origin: c.Origin.Add(lo)}
+	if lo.X > hi.X || lo.Y > hi.Y {
+		return out
+	}
+	out.g = cl.NewGrid[Cell](hi.X-lo.X+1, hi.Y-lo.Y+1)
+	for y := range out.g.H {
+		copy(out.g.Cells[y*out.g.W:(y+1)*out.g.W], c.g.Cells[(lo.Y+y)*c.g.W+lo.X:])
+	}
+	return out
+}
+
+// View crops c to at most w by h cells around center, shifted to stay
+// inside c.
+func (c *Canvas) View(center cl.Vec2, w, h int) *Canvas {
+	center = center.Sub(c.Origin)
+	x := min(max(center.X-w/2, 0), max(c.g.W-w, 0))
+	y := min(max(center.Y-h/2, 0), max(c.g.H-h, 0))
+	lo := c.Origin.Add(cl.V2(x, y))
+	return c.Crop(lo, lo.Add(cl.V2(w-1, h-1)))
+}
+
+// Renderer draws canvases to a terminal, each over the previous one,
+// at most FPS times a second, with the cursor hidden until Close. While
+// its output is not a terminal, see cl.IsTerminal, it writes every
+// canvas as plain text, one after the other, without waiting.
+type Renderer struct {
+	out    io.Writer
+	ansi   bool
+	hidden bool
+	FPS    int
+	last   time.Time
+	lines  int
+	sb     strings.Builder
+}
+
+// NewRenderer returns a Renderer writing to w, with no frame rate
+// limit if fps is 0.
+func NewRenderer(w io.Writer, fps int) *Renderer {
+	return &Renderer{out: w, FPS: fps}
+}
+
+// Draw writes c followed by caption, if not empty.
+func (r *Renderer) Draw(c *Canvas, caption string) error {
+	r.sb.Reset()
+	r.ansi = cl.IsTerminal(r.out)
+	switch {
+	case !r.ansi:
+		r.lines = 0
+	case !r.hidden:
+		r.sb.WriteString("\x1b[?25l")
+		r.hidden = true
+	case r.lines > 0:
+		fmt.Fprintf(&r.sb, "\x1b[%dA\r", r.lines)
+	}
+	g := c.g
+	for y := range g.H {
+		var cur Style
+		for _, cell := range g.Cells[y*g.W : (y+1)*g.W] {
+			if r.ansi && cell.Style != cur {
+				r.sb.WriteString("\x1b[0m")
+				if cell.Style != "" {
+					fmt.Fprintf(&r.sb, "\x1b[%sm", cell.Style)
+				}
+				cur = cell.Style
+			}
+			if cell.Ch == 0 {
+				cell.Ch = ' '
+			}
+			r.sb.WriteRune(cell.Ch)
+		}
+		if r.ansi {
+			if cur != "" {
+				r.sb.WriteString("\x1b[0m")
+			}
+			r.sb.WriteString("\x1b[K")
+		}
+		r.sb.WriteByte('\n')
+	}
+	r.lines = g.H
+	if caption != "" {
+		r.sb.WriteString(caption)
+		if r.ansi {
+			r.sb.WriteString("\x1b[K")
+		}
+		r.sb.WriteByte('\n')
+		r.lines += strings.Count(caption, "\n") + 1
+	}
+	if r.ansi {
+		// clear what is left of a taller previous frame
+		r.sb.WriteString("\x1b[J")
+	} else {
+		r.sb.WriteByte('\n')
+	}
+	r.wait()
+	_, err := io.WriteString(r.out, r.sb.String())
+	return err
+}
+
+// Close shows the cursor again and resets the colors, leaving the
+// last canvas on screen.
+func (r *Renderer) Close() error {
+	if !r.hidden {
+		return nil
+	}
+	r.hidden = false
+	_, err := io.WriteString(r.out, "\x1b[0m\x1b[?25h")
+	return err
+}
+
+// wait sleeps until a frame after the previous one.
+func (r *Renderer) wait() {
+	if !r.ansi || r.FPS <= 0 {
+		return
+	}
+	if d := time.Second/time.Duration(r.FPS) - time.Since(r.last); d > 0 && !r.last.IsZero() {
+		time.Sleep(d)
+	}
+	r.last = time.Now()
+}
@@ -0,0 +1,81 @@
+package term
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lindeneg/aoc/cl"
+)
+
+// tty is a buffer that claims to be a terminal.
+type tty struct {
+	bytes.Buffer
+}
+
+func (*tty) IsTerminal() bool { return true }
+
+func grid() cl.Grid[byte] {
+	return cl.GridOf([][]byte{[]byte("#.."), []byte(".#."), []byte("...")})
+}
+
+func TestPlain(t *testing.T) {
+	var b bytes.Buffer
+	r := NewRenderer(&b, 0)
+	c := Bytes(grid()).Class('#', Red).Path([]cl.Vec2{cl.V2(1, 0), cl.V2(2, 1)}, 'O', Green)
+	r.Draw(c, "one")
+	r.Draw(c.Crop(cl.V2(1, 1), cl.V2(5, 5)), "")
+	r.Close()
+	want := "#O.\n.#O\n...\none\n\n#O\n..\n\n"
+	if b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+}
+
+func TestView(t *testing.T) {
+	c := Bytes(cl.GridOf([][]byte{[]byte("abcde"), []byte("fghij"), []byte("klmno")}))
+	tests := []struct {
+		center cl.Vec2
+		w, h   int
+		want   string
+	}{
+		{cl.V2(0, 0), 2, 2, "ab\nfg\n"},
+		{cl.V2(4, 2), 2, 2, "ij\nno\n"},
+		{cl.V2(2, 1), 3, 1, "ghi\n"},
+		{cl.V2(2, 1), 9, 9, "abcde\nfghij\nklmno\n"},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		NewRenderer(&b, 0).Draw(c.View(tt.center, tt.w, tt.h), "")
+		if got := strings.TrimSuffix(b.String(), "\n"); got != tt.want {
+			t.Errorf("View(%v, %d, %d) = %q, want %q", tt.center, tt.w, tt.h, got, tt.want)
+		}
+	}
+	// positions stay those of the source grid after cropping
+	v := c.View(cl.V2(4, 2), 2, 2).Style(cl.V2(4, 2), Bold)
+	if v.Origin != cl.V2(3, 1) || v.cell(cl.V2(4, 2)).Style != Bold {
+		t.Errorf("origin %v", v.Origin)
+	}
+}
+
+func TestANSI(t *testing.T) {
+	var b tty
+	r := NewRenderer(&b, 0)
+	c := Bytes(grid()).Class('#', Red)
+	r.Draw(c, "x")
+	first := b.Len()
+	r.Draw(c, "x")
+	out := b.String()
+	switch {
+	case !strings.HasPrefix(out, "\x1b[?25l"):
+		t.Errorf("cursor not hidden: %q", out)
+	case !strings.Contains(out, "\x1b[0m\x1b[31m#\x1b[0m"):
+		t.Errorf("no red #: %q", out)
+	case !strings.HasPrefix(out[first:], "\x1b[4A\r"):
+		t.Errorf("second frame does not move up over the first: %q", out[first:])
+	}
+	r.Close()
+	if !strings.HasSuffix(b.String(), "\x1b[0m\x1b[?25h") {
+		t.Errorf("Close does not restore the cursor: %q", b.String())
+	}
+}
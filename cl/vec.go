@@ -0,0 +1,217 @@
+package cl
+
+import (
+	"fmt"
+	"math"
+)
+
+// Dir is one of the four grid directions, in clockwise order, on
+// grids where y grows downwards.
+type Dir int
+
+const (
+	Up Dir = iota
+	Right
+	Down
+	Left
+)
+
+var Dirs = [4]Dir{Up, Right, Down, Left}
+
+var (
+	dirs4 = [...]Vec2{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+	dirs8 = [...]Vec2{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+)
+
+// ParseDir accepts arrows ^>v<, compass points NESW and UDLR.
+func ParseDir(c byte) (Dir, error) {
+	switch c {
+	case '^', 'N', 'U':
+		return Up, nil
+	case '>', 'E', 'R':
+		return Right, nil
+	case 'v', 'S', 'D':
+		return Down, nil
+	case '<', 'W', 'L':
+		return Left, nil
+	}
+	return 0, fmt.Errorf("invalid direction %q", c)
+}
+
+func DirOf(c byte) Dir {
+	d, err := ParseDir(c)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Dir) TurnRight() Dir {
+	return (d + 1) & 3
+}
+
+func (d Dir) TurnLeft() Dir {
+	return (d + 3) & 3
+}
+
+func (d Dir) Reverse() Dir {
+	return (d + 2) & 3
+}
+
+func (d Dir) Vec() Vec2 {
+	return dirs4[d&3]
+}
+
+func (d Dir) Arrow() byte {
+	return "^>v<"[d&3]
+}
+
+func (d Dir) String() string {
+	return [...]string{"Up", "Right", "Down", "Left"}[d&3]
+}
+
+func (v Vec2) Move(d Dir) Vec2 {
+	return v.Add(d.Vec())
+}
+
+func (v Vec2) Neg() Vec2 {
+	return Vec2{-v.X, -v.Y}
+}
+
+func (v Vec2) Abs() Vec2 {
+	return Vec2{AbsInt(v.X), AbsInt(v.Y)}
+}
+
+func (v Vec2) Manhattan(v2 Vec2) int {
+	return AbsInt(v.X-v2.X) + AbsInt(v.Y-v2.Y)
+}
+
+func (v Vec2) Chebyshev(v2 Vec2) int {
+	return max(AbsInt(v.X-v2.X), AbsInt(v.Y-v2.Y))
+}
+
+// Euclid is the exact Euclidean distance, unlike Distance.
+func (v Vec2) Euclid(v2 Vec2) float64 {
+	return math.Hypot(float64(v2.X-v.X), float64(v2.Y-v.Y))
+}
+
+// RotateCW turns v a quarter clockwise around the origin, so Up
+// becomes Right.
+func (v Vec2) RotateCW() Vec2 {
+	return Vec2{-v.Y, v.X}
+}
+
+func (v Vec2) RotateCCW() Vec2 {
+	return Vec2{v.Y, -v.X}
+}
+
+// Neighbors4 returns the orthogonal neighbors in Dir order.
+func (v Vec2) Neighbors4() [4]Vec2 {
+	var n [4]Vec2
+	for i, d := range dirs4 {
+		n[i] = v.Add(d)
+	}
+	return n
+}
+
+// Neighbors8 returns all neighbors clockwise, starting above.
+func (v Vec2) Neighbors8() [8]Vec2 {
+	var n [8]Vec2
+	for i, d := range dirs8 {
+		n[i] = v.Add(d)
+	}
+	return n
+}
+
+func (v Vec3) Add(v2 Vec3) Vec3 {
+	return Vec3{v.X + v2.X, v.Y + v2.Y, v.Z + v2.Z}
+}
+
+func (v Vec3) Neg() Vec3 {
+	return Vec3{-v.X, -v.Y, -v.Z}
+}
+
+func (v Vec3) Manhattan(v2 Vec3) int {
+	return AbsInt(v.X-v2.X) + AbsInt(v.Y-v2.Y) + AbsInt(v.Z-v2.Z)
+}
+
+func (v Vec3) Chebyshev(v2 Vec3) int {
+	return max(AbsInt(v.X-v2.X), AbsInt(v.Y-v2.Y), AbsInt(v.Z-v2.Z))
+}
+
+// Neighbors6 returns the neighbors that share a face with v.
+func (v Vec3) Neighbors6() [6]Vec3 {
+	return [6]Vec3{
+		{v.X - 1, v.Y, v.Z}, {v.X + 1, v.Y, v.Z},
+		{v.X, v.Y - 1, v.Z}, {v.X, v.Y + 1, v.Z},
+		{v.X, v.Y, v.Z - 1}, {v.X, v.Y, v.Z + 1},
+	}
+}
+
+// Neighbors26 returns every neighbor of v, including diagonals.
+func (v Vec3) Neighbors26() []Vec3 {
+	n := make([]Vec3, 0, 26)
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dz := -1; dz <= 1; dz++ {
+				if dx != 0 || dy != 0 || dz != 0 {
+					n = append(n, Vec3{v.X + dx, v.Y + dy, v.Z + dz})
+				}
+			}
+		}
+	}
+	return n
+}
+
+type Vec4 struct {
+	X, Y, Z, W int
+}
+
+func V4(x, y, z, w int) Vec4 {
+	return Vec4{x, y, z, w}
+}
+
+func (v Vec4) Add(v2 Vec4) Vec4 {
+	return Vec4{v.X + v2.X, v.Y + v2.Y, v.Z + v2.Z, v.W + v2.W}
+}
+
+func (v Vec4) Sub(v2 Vec4) Vec4 {
+	return Vec4{v.X - v2.X, v.Y - v2.Y, v.Z - v2.Z, v.W - v2.W}
+}
+
+func (v Vec4) Scale(s int) Vec4 {
+	return Vec4{v.X * s, v.Y * s, v.Z * s, v.W * s}
+}
+
+func (v Vec4) Neg() Vec4 {
+	return Vec4{-v.X, -v.Y, -v.Z, -v.W}
+}
+
+func (v Vec4) Manhattan(v2 Vec4) int {
+	return AbsInt(v.X-v2.X) + AbsInt(v.Y-v2.Y) + AbsInt(v.Z-v2.Z) + AbsInt(v.W-v2.W)
+}
+
+func (v Vec4) Chebyshev(v2 Vec4) int {
+	return max(AbsInt(v.X-v2.X), AbsInt(v.Y-v2.Y), AbsInt(v.Z-v2.Z), AbsInt(v.W-v2.W))
+}
+
+// Neighbors80 returns every neighbor of v, including diagonals.
+func (v Vec4) Neighbors80() []Vec4 {
+	n := make([]Vec4, 0, 80)
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dz := -1; dz <= 1; dz++ {
+				for dw := -1; dw <= 1; dw++ {
+					if dx != 0 || dy != 0 || dz != 0 || dw != 0 {
+						n = append(n, Vec4{v.X + dx, v.Y + dy, v.Z + dz, v.W + dw})
+					}
+				}
+			}
+		}
+	}
+	return n
+}
+
+func (v Vec4) String() string {
+	return fmt.Sprintf("(%d,%d,%d,%d)", v.X, v.Y, v.Z, v.W)
+}
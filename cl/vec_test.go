@@ -0,0 +1,84 @@
+package cl
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	for _, d := range Dirs {
+		if d.TurnRight().TurnLeft() != d || d.Reverse().Reverse() != d || d.TurnRight().TurnRight() != d.Reverse() {
+			t.Errorf("%s: turns do not add up", d)
+		}
+		if d.Vec().RotateCW() != d.TurnRight().Vec() || d.Vec().RotateCCW() != d.TurnLeft().Vec() {
+			t.Errorf("%s: rotation does not match turning", d)
+		}
+		if got := DirOf(d.Arrow()); got != d {
+			t.Errorf("DirOf(%q) = %s, want %s", d.Arrow(), got, d)
+		}
+		if V2(3, 3).Move(d).Move(d.Reverse()) != V2(3, 3) {
+			t.Errorf("%s: moving back does not return", d)
+		}
+	}
+	if Up.Vec() != V2(0, -1) || Right.Vec() != V2(1, 0) {
+		t.Error("Up is not -y or Right is not +x")
+	}
+	for c, want := range map[byte]Dir{'N': Up, 'E': Right, 'D': Down, 'L': Left} {
+		if d, err := ParseDir(c); err != nil || d != want {
+			t.Errorf("ParseDir(%q) = %s, %v", c, d, err)
+		}
+	}
+	if _, err := ParseDir('x'); err == nil {
+		t.Error("ParseDir('x') did not fail")
+	}
+}
+
+func TestDistances(t *testing.T) {
+	a, b := V2(1, -2), V2(-3, 1)
+	if a.Manhattan(b) != 7 || a.Chebyshev(b) != 4 || a.Euclid(b) != 5 {
+		t.Errorf("Manhattan %d Chebyshev %d Euclid %v", a.Manhattan(b), a.Chebyshev(b), a.Euclid(b))
+	}
+	if d := V2(0, 0).Euclid(V2(1, 1)); math.Abs(d-math.Sqrt2) > 1e-12 {
+		t.Errorf("Euclid diagonal %v", d)
+	}
+	p, q := V4(1, 2, 3, 4), V4(0, 4, 3, -1)
+	if p.Manhattan(q) != 8 || p.Chebyshev(q) != 5 || p.Sub(q).Add(q) != p || p.Neg().Scale(-1) != p {
+		t.Errorf("Vec4 %d %d", p.Manhattan(q), p.Chebyshev(q))
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	v := V2(5, 5)
+	n4 := v.Neighbors4()
+	for i, d := range Dirs {
+		if n4[i] != v.Move(d) {
+			t.Errorf("Neighbors4[%d] = %v, want %v", i, n4[i], v.Move(d))
+		}
+	}
+	seen := map[Vec2]bool{}
+	for _, n := range v.Neighbors8() {
+		if v.Chebyshev(n) != 1 || seen[n] {
+			t.Errorf("Neighbors8 has %v", n)
+		}
+		seen[n] = true
+	}
+	counts := []struct {
+		name string
+		n    int
+		want int
+	}{
+		{"Neighbors6", len(V3(0, 0, 0).Neighbors6()), 6},
+		{"Neighbors26", len(V3(0, 0, 0).Neighbors26()), 26},
+		{"Neighbors80", len(V4(0, 0, 0, 0).Neighbors80()), 80},
+	}
+	for _, c := range counts {
+		if c.n != c.want {
+			t.Errorf("%s has %d, want %d", c.name, c.n, c.want)
+		}
+	}
+	for _, n := range V4(1, 1, 1, 1).Neighbors80() {
+		if n.Chebyshev(V4(1, 1, 1, 1)) != 1 {
+			t.Errorf("Neighbors80 has %v", n)
+		}
+	}
+}
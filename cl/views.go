@@ -0,0 +1,127 @@
+package cl
+
+import (
+	"bytes"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// views holds the derived forms of an Input. It is shared by every
+// copy of the Input, so each view is computed at most once.
+type views struct {
+	lines    lazy[[]string]
+	ints     lazy[[][]int]
+	fields   lazy[[][]string]
+	sections lazy[[]Input]
+	rows     lazy[[][]byte]
+}
+
+type lazy[T any] struct {
+	once sync.Once
+	v    T
+}
+
+func (l *lazy[T]) get(fn func() T) T {
+	l.once.Do(func() { l.v = fn() })
+	return l.v
+}
+
+// cached returns the views of in, an Input that was not created by
+// ParseInput gets fresh views on every call.
+func (in Input) cached() *views {
+	if in.views == nil {
+		return new(views)
+	}
+	return in.views
+}
+
+// The views below are computed from B on first use, independent of
+// the separator the Input was parsed with. They are shared between
+// calls and must not be modified.
+
+// All yields the records of R1 with their index.
+func (in Input) All() iter.Seq2[int, string] {
+	return slices.All(in.R1)
+}
+
+// Lines splits the input on newlines.
+func (in Input) Lines() []string {
+	return in.cached().lines.get(func() []string {
+		return strings.Split(string(in.B), "\n")
+	})
+}
+
+// Ints returns every signed integer of every line.
+func (in Input) Ints() [][]int {
+	return in.cached().ints.get(func() [][]int {
+		lines := in.Lines()
+		out := make([][]int, len(lines))
+		for i, line := range lines {
+			out[i] = Ints(line)
+		}
+		return out
+	})
+}
+
+// Fields splits every line on runs of whitespace.
+func (in Input) Fields() [][]string {
+	return in.cached().fields.get(func() [][]string {
+		lines := in.Lines()
+		out := make([][]string, len(lines))
+		for i, line := range lines {
+			out[i] = strings.Fields(line)
+		}
+		return out
+	})
+}
+
+// Sections splits the input on blank lines, each block parsed as Lines.
+func (in Input) Sections() []Input {
+	return in.cached().sections.get(func() []Input {
+		blocks := bytes.Split(in.B, []byte("\n\n"))
+		out := make([]Input, len(blocks))
+		for i, b := range blocks {
+			out[i], _ = ParseInput(b, InputOptions{Sep: "\n", AllowEmpty: true})
+		}
+		return out
+	})
+}
+
+// Rows returns the bytes of every line, see ByteGrid for a Grid.
+func (in Input) Rows() [][]byte {
+	return in.cached().rows.get(func() [][]byte {
+		return bytes.Split(in.B, []byte("\n"))
+	})
+}
+
+// Ints returns every signed integer in s. A minus sign only counts
+// when it does not follow a digit, so ranges like 3-5 yield 3 and 5.
+func Ints(s string) []int {
+	var out []int
+	for i := 0; i < len(s); {
+		start := i
+		if s[i] == '-' && i+1 < len(s) && isDigit(s[i+1]) && (i == 0 || !isDigit(s[i-1])) {
+			i++
+		}
+		if !isDigit(s[i]) {
+			i++
+			continue
+		}
+		n := 0
+		for i < len(s) && isDigit(s[i]) {
+			n = n*10 + int(s[i]-'0')
+			i++
+		}
+		if s[start] == '-' {
+			n = -n
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,34 @@
+package cl
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestViews(t *testing.T) {
+	in, err := ParseInput([]byte("a 1 -2\nb 3-4\n\nc\r\n"), Lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := in.Lines(); !slices.Equal(got, []string{"a 1 -2", "b 3-4", "", "c"}) {
+		t.Errorf("Lines() = %q", got)
+	}
+	ints := in.Ints()
+	if !slices.Equal(ints[0], []int{1, -2}) || !slices.Equal(ints[1], []int{3, 4}) || len(ints[2]) != 0 {
+		t.Errorf("Ints() = %v", ints)
+	}
+	if got := in.Fields()[1]; !slices.Equal(got, []string{"b", "3-4"}) {
+		t.Errorf("Fields()[1] = %q", got)
+	}
+	secs := in.Sections()
+	if len(secs) != 2 || !slices.Equal(secs[1].R1, []string{"c"}) {
+		t.Errorf("Sections() = %v", secs)
+	}
+	rows := in.Rows()
+	if len(rows) != 4 || string(rows[3]) != "c" {
+		t.Errorf("Rows() = %q", rows)
+	}
+	if &in.Rows()[0][0] != &rows[0][0] {
+		t.Error("Rows() is not cached")
+	}
+}
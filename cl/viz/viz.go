@@ -0,0 +1,139 @@
+// Package viz draws grids as PNG images and animated GIFs.
+package viz
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/lindeneg/aoc/cl"
+)
+
+// Palette maps the bytes of a grid to colors. It has at most 256
+// colors, so that it works for GIFs.
+type Palette struct {
+	colors color.Palette
+	index  [256]uint8
+}
+
+// NewPalette colors the bytes in colors as given and every other byte
+// as bg.
+func NewPalette(bg color.Color, colors map[byte]color.Color) *Palette {
+	p := &Palette{colors: color.Palette{bg}}
+	for b := range 256 {
+		c, ok := colors[byte(b)]
+		if !ok {
+			continue
+		}
+		cl.AssertM(len(p.colors) < 256, "palette of more than 256 colors")
+		p.index[b] = uint8(len(p.colors))
+		p.colors = append(p.colors, c)
+	}
+	return p
+}
+
+// Image draws g with every cell as a square of scale by scale pixels.
+func (p *Palette) Image(g cl.Grid[byte], scale int) *image.Paletted {
+	cl.AssertM(scale > 0, "scale %d", scale)
+	img := image.NewPaletted(image.Rect(0, 0, g.W*scale, g.H*scale), p.colors)
+	for y := range g.H {
+		for x := range g.W {
+			i := p.index[g.Cells[y*g.W+x]]
+			for dy := range scale {
+				row := img.Pix[(y*scale+dy)*img.Stride:]
+				for dx := range scale {
+					row[x*scale+dx] = i
+				}
+			}
+		}
+	}
+	return img
+}
+
+// Bytes maps every cell of g to a byte to draw.
+func Bytes[T any](g cl.Grid[T], fn func(T) byte) cl.Grid[byte] {
+	out := cl.NewGrid[byte](g.W, g.H)
+	for i, c := range g.Cells {
+		out.Cells[i] = fn(c)
+	}
+	return out
+}
+
+// WritePNG encodes img as a PNG to w.
+func WritePNG(w io.Writer, img image.Image) error {
+	return png.Encode(w, img)
+}
+
+// SavePNG writes img to path, creating its directory if needed.
+func SavePNG(path string, img image.Image) error {
+	return save(path, func(w io.Writer) error { return WritePNG(w, img) })
+}
+
+// Recorder collects the frames of a simulation for an animated GIF.
+type Recorder struct {
+	Palette *Palette
+	Scale   int
+	// Delay is the time every frame is shown in 100ths of a second.
+	Delay int
+	// Every keeps only every n-th frame added, starting with the
+	// first, to keep long simulations short.
+	Every int
+	added int
+	anim  gif.GIF
+}
+
+// NewRecorder returns a Recorder keeping every frame, drawn with p at
+// scale and shown for delay 100ths of a second each.
+func NewRecorder(p *Palette, scale, delay int) *Recorder {
+	return &Recorder{Palette: p, Scale: scale, Delay: delay, Every: 1}
+}
+
+// Add draws g as the next frame.
+func (r *Recorder) Add(g cl.Grid[byte]) {
+	r.added++
+	if r.Every > 1 && (r.added-1)%r.Every != 0 {
+		return
+	}
+	r.anim.Image = append(r.anim.Image, r.Palette.Image(g, r.Scale))
+	r.anim.Delay = append(r.anim.Delay, r.Delay)
+}
+
+// Len returns the number of frames kept.
+func (r *Recorder) Len() int {
+	return len(r.anim.Image)
+}
+
+// WriteGIF writes the frames as a GIF that loops forever. Frames of
+// different sizes are drawn at the top left of the largest one.
+func (r *Recorder) WriteGIF(w io.Writer) error {
+	anim := r.anim
+	for _, img := range anim.Image {
+		anim.Config.Width = max(anim.Config.Width, img.Rect.Dx())
+		anim.Config.Height = max(anim.Config.Height, img.Rect.Dy())
+	}
+	return gif.EncodeAll(w, &anim)
+}
+
+// SaveGIF writes the frames to path, creating its directory if needed.
+func (r *Recorder) SaveGIF(path string) error {
+	return save(path, r.WriteGIF)
+}
+
+func save(path string, write func(io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,55 @@
+package viz
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"testing"
+
+	"github.com/lindeneg/aoc/cl"
+)
+
+var (
+	bg  = color.RGBA{0, 0, 0, 255}
+	red = color.RGBA{255, 0, 0, 255}
+)
+
+func TestImage(t *testing.T) {
+	p := NewPalette(bg, map[byte]color.Color{'#': red})
+	img := p.Image(cl.GridOf([][]byte{[]byte("#."), []byte(".#")}), 3)
+	if b := img.Bounds(); b.Dx() != 6 || b.Dy() != 6 {
+		t.Fatalf("bounds %v", b)
+	}
+	for _, tt := range []struct {
+		x, y int
+		want color.Color
+	}{{0, 0, red}, {2, 2, red}, {3, 0, bg}, {5, 5, red}, {0, 5, bg}} {
+		if got := img.At(tt.x, tt.y); got != tt.want {
+			t.Errorf("At(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder(NewPalette(bg, map[byte]color.Color{'#': red}), 2, 5)
+	r.Every = 2
+	for i := range 5 {
+		g := cl.NewGrid[byte](i+1, 1)
+		g.Cells[i] = '#'
+		r.Add(g)
+	}
+	if r.Len() != 3 {
+		t.Fatalf("kept %d frames, want 3", r.Len())
+	}
+	var b bytes.Buffer
+	if err := r.WriteGIF(&b); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != 3 || anim.Config.Width != 10 || anim.Config.Height != 2 || anim.Delay[0] != 5 {
+		t.Errorf("%d frames of %dx%d, delay %v", len(anim.Image), anim.Config.Width, anim.Config.Height, anim.Delay)
+	}
+}
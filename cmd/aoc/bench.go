@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lindeneg/aoc/cl"
+)
+
+type benchConfig struct {
+	enabled   bool
+	time      time.Duration
+	baseline  string
+	update    bool
+	tolerance float64
+	previous  cl.Baseline
+	results   []cl.BenchResult
+	regressed int
+}
+
+func (b *benchConfig) load() error {
+	if b.baseline == "" {
+		if b.update {
+			return errors.New("-baseline-update requires -baseline")
+		}
+		return nil
+	}
+	prev, err := cl.LoadBaseline(b.baseline)
+	if err != nil {
+		return err
+	}
+	b.previous = prev
+	return nil
+}
+
+func benchDay(cfg config, d *cl.Day) {
+	opts := cl.DefaultBenchOptions
+	opts.Time = cfg.bench.time
+	opts.Timeout = cfg.timeout
+	for _, r := range d.Bench(cfg.report, dayDir(cfg.root, d.Year, d.Day), opts) {
+		line := r.String()
+		if delta, regressed, ok := cfg.bench.previous.Compare(r, cfg.bench.tolerance); ok {
+			line += fmt.Sprintf("\t%+.1f%%", delta*100)
+			if regressed {
+				line += " REGRESSION"
+				cfg.bench.regressed++
+			}
+		}
+		fmt.Fprintln(cfg.stdout, line)
+		cfg.bench.results = append(cfg.bench.results, r)
+	}
+}
+
+func (b *benchConfig) finish() error {
+	if !b.enabled {
+		return nil
+	}
+	if b.update {
+		b.previous.Update(b.results...)
+		if err := b.previous.Save(b.baseline); err != nil {
+			return err
+		}
+	}
+	if b.regressed > 0 {
+		return fmt.Errorf("%d benchmark regressions against %s", b.regressed, b.baseline)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lindeneg/aoc/cl"
+)
+
+// TestBenchDayTolerance checks that only cases slower than their
+// baseline by more than -tolerance are flagged, and that -timeout
+// limits the checking run.
+func TestBenchDayTolerance(t *testing.T) {
+	root := t.TempDir()
+	dir := dayDir(root, 1, 1)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "example.in"), []byte("1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	block := make(chan struct{})
+	defer close(block)
+	d := &cl.Day{Year: 1, Day: 1, Opts: cl.Lines}
+	cl.Solve(d, 1, func(cl.Input) int { return 1 }).Example("example.in", 1)
+	cl.Solve(d, 2, func(cl.Input) int { return 2 }).Example("example.in", 2)
+	cl.Solve(d, 3, func(cl.Input) int {
+		<-block
+		return 3
+	}).Example("example.in", 3)
+	key := func(part int) string {
+		return cl.BenchResult{Year: 1, Day: 1, Name: cl.ExampleName, Part: part, File: "example.in"}.Key()
+	}
+	var out bytes.Buffer
+	baseline := filepath.Join(root, "baseline.json")
+	cfg := config{
+		root:    root,
+		report:  &cl.Report{},
+		timeout: 10 * time.Millisecond,
+		stdout:  &out,
+		bench: &benchConfig{
+			enabled:   true,
+			time:      time.Millisecond,
+			baseline:  baseline,
+			tolerance: 0.2,
+			previous: cl.Baseline{
+				// part 1 can only be slower than a nanosecond, part 2
+				// only faster than an hour
+				key(1): {Median: time.Nanosecond},
+				key(2): {Median: time.Hour},
+			},
+		},
+	}
+	benchDay(cfg, d)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "REGRESSION") || strings.Contains(lines[1], "REGRESSION") {
+		t.Errorf("got output\n%s\nwant part 1 flagged only", out.String())
+	}
+	if cfg.bench.regressed != 1 {
+		t.Errorf("%d regressions, want 1", cfg.bench.regressed)
+	}
+	if res := cfg.report.Results; len(res) != 3 || !strings.HasPrefix(res[2].Error, "timed out") {
+		t.Errorf("got %+v, want part 3 timed out", res)
+	}
+	if err := cfg.bench.finish(); err == nil {
+		t.Error("finish did not report the regression")
+	}
+}
@@ -0,0 +1,39 @@
+// Code generated by aoc start; DO NOT EDIT.
+
+package main
+
+import (
+	_ "github.com/lindeneg/aoc/2015/01"
+	_ "github.com/lindeneg/aoc/2015/02"
+	_ "github.com/lindeneg/aoc/2015/03"
+	_ "github.com/lindeneg/aoc/2015/04"
+	_ "github.com/lindeneg/aoc/2015/05"
+	_ "github.com/lindeneg/aoc/2015/06"
+	_ "github.com/lindeneg/aoc/2015/07"
+	_ "github.com/lindeneg/aoc/2015/08"
+	_ "github.com/lindeneg/aoc/2015/09"
+	_ "github.com/lindeneg/aoc/2015/10"
+	_ "github.com/lindeneg/aoc/2015/11"
+	_ "github.com/lindeneg/aoc/2015/12"
+	_ "github.com/lindeneg/aoc/2015/13"
+	_ "github.com/lindeneg/aoc/2015/14"
+	_ "github.com/lindeneg/aoc/2015/15"
+	_ "github.com/lindeneg/aoc/2024/01"
+	_ "github.com/lindeneg/aoc/2024/02"
+	_ "github.com/lindeneg/aoc/2024/03"
+	_ "github.com/lindeneg/aoc/2024/04"
+	_ "github.com/lindeneg/aoc/2024/05"
+	_ "github.com/lindeneg/aoc/2024/06"
+	_ "github.com/lindeneg/aoc/2024/07"
+	_ "github.com/lindeneg/aoc/2024/08"
+	_ "github.com/lindeneg/aoc/2024/09"
+	_ "github.com/lindeneg/aoc/2024/10"
+	_ "github.com/lindeneg/aoc/2024/11"
+	_ "github.com/lindeneg/aoc/2024/12"
+	_ "github.com/lindeneg/aoc/2024/13"
+	_ "github.com/lindeneg/aoc/2024/14"
+	_ "github.com/lindeneg/aoc/2024/15"
+	_ "github.com/lindeneg/aoc/2024/16"
+	_ "github.com/lindeneg/aoc/2024/17"
+	_ "github.com/lindeneg/aoc/2024/18"
+)
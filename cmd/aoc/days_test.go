@@ -0,0 +1,22 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lindeneg/aoc/cl"
+	"github.com/lindeneg/aoc/cl/cltest"
+)
+
+func TestDays(t *testing.T) {
+	root, err := findRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl.DefaultInputs.Roots = append(cl.DefaultInputs.Roots, filepath.Join(root, "inputs"))
+	for _, d := range cl.Days() {
+		t.Run(d.String(), func(t *testing.T) {
+			cltest.Day(t, d, dayDir(root, d.Year, d.Day))
+		})
+	}
+}
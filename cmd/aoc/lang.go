@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sync"
+	"text/template"
+
+	"github.com/lindeneg/aoc/cl"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+type lang struct {
+	filename string
+	run      func(cfg config, year, day int) error
+}
+
+const (
+	goFile = "day.go"
+	tsFile = "index.ts"
+)
+
+var (
+	golang = lang{filename: goFile, run: runGo}
+	ts     = lang{filename: tsFile, run: runTS}
+)
+
+// TODO: what if I wanna have both js and go for the same year?
+var years = map[int]lang{
+	2015: golang,
+	2024: golang,
+	2025: ts,
+}
+
+func langFor(year int) (lang, error) {
+	l, ok := years[year]
+	if !ok {
+		return lang{}, fmt.Errorf("no implementation for year %d", year)
+	}
+	return l, nil
+}
+
+func render(path, name string, data any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return templates.ExecuteTemplate(f, name, data)
+}
+
+func (l lang) render(path string, year, day int) error {
+	return render(path, l.filename+".tmpl", struct{ Year, Day int }{year, day})
+}
+
+// writeDays regenerates days.go so the binary links every go day
+// found on disk. It only takes effect the next time aoc is built.
+func writeDays(root string) error {
+	var pkgs []string
+	for year, l := range years {
+		if l.filename != goFile {
+			continue
+		}
+		for day := 1; day <= 25; day++ {
+			dir := dayDir(root, year, day)
+			if _, err := os.Stat(filepath.Join(dir, l.filename)); err == nil {
+				rel, _ := filepath.Rel(root, dir)
+				pkgs = append(pkgs, filepath.ToSlash(rel))
+			}
+		}
+	}
+	slices.Sort(pkgs)
+	return render(filepath.Join(root, "cmd", "aoc", "days.go"), "days.go.tmpl", pkgs)
+}
+
+func runGo(cfg config, year, day int) error {
+	d, ok := cl.Lookup(year, day)
+	if !ok {
+		return fmt.Errorf("%d day %d is not registered, rebuild aoc", year, day)
+	}
+	if cfg.bench.enabled {
+		benchDay(cfg, d)
+		return nil
+	}
+	d.RunContext(context.Background(), cfg.report, dayDir(cfg.root, year, day), cfg.timeout)
+	return nil
+}
+
+func runTS(cfg config, year, day int) error {
+	dir := dayDir(cfg.root, year, day)
+	cmd := exec.Command("npx", "-y", "tsx", filepath.Join(dir, tsFile), dir)
+	cmd.Dir = cfg.root
+	cmd.Stdout = cfg.stdout
+	cmd.Stderr = cfg.stderr
+	return cmd.Run()
+}
+
+func runDay(cfg config, year, day int) error {
+	l, err := langFor(year)
+	if err != nil {
+		return err
+	}
+	return l.run(cfg, year, day)
+}
+
+func runYear(cfg config, year int) error {
+	l, err := langFor(year)
+	if err != nil {
+		return err
+	}
+	if l.filename == goFile && !cfg.bench.enabled {
+		return runGoYear(cfg, year)
+	}
+	for day := 1; day <= 25; day++ {
+		if _, err := os.Stat(dayDir(cfg.root, year, day)); err != nil {
+			continue
+		}
+		fmt.Fprintf(cfg.stdout, "Day %d:\n", day)
+		if err := runDay(cfg, year, day); err != nil {
+			fmt.Fprintf(cfg.stderr, "error: %d day %d: %v\n", year, day, err)
+		}
+		fmt.Fprint(cfg.stdout, "\n\n")
+	}
+	return nil
+}
+
+// runGoYear runs the registered days of year on cfg.jobs workers and
+// prints a table of their results sorted by day once all are done.
+// Cases that time out are abandoned, so it does not wait for their
+// solvers to return.
+func runGoYear(cfg config, year int) error {
+	days := make(chan *cl.Day)
+	out := cfg.report.Out
+	cfg.report.Out = nil
+	var wg sync.WaitGroup
+	for range max(cfg.jobs, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range days {
+				d.RunContext(context.Background(), cfg.report, dayDir(cfg.root, d.Year, d.Day), cfg.timeout)
+			}
+		}()
+	}
+	for _, d := range cl.Days() {
+		if d.Year == year {
+			days <- d
+		}
+	}
+	close(days)
+	wg.Wait()
+	cfg.report.Sort()
+	if out != nil {
+		return cfg.report.WriteTable(out)
+	}
+	return nil
+}
+
+func listDays(cfg config, year int) error {
+	for _, d := range cl.Days() {
+		if year != 0 && d.Year != year {
+			continue
+		}
+		fmt.Fprint(cfg.stdout, d)
+		for _, p := range d.Parts {
+			for _, c := range p.Cases {
+				fmt.Fprintf(cfg.stdout, "\t%s %d (%s)", c.Name, p.N, c.File)
+			}
+		}
+		fmt.Fprintln(cfg.stdout)
+	}
+	return nil
+}
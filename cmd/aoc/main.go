@@ -0,0 +1,184 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/lindeneg/aoc/cl"
+)
+
+const usage = "Usage: aoc [flags] (list [year] | year all | year day (start|run))"
+
+var errUsage = errors.New(usage)
+
+type config struct {
+	root    string
+	baseURL string
+	format  string
+	report  *cl.Report
+	bench   *benchConfig
+	jobs    int
+	timeout time.Duration
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+func main() {
+	cfg := config{report: &cl.Report{}, bench: &benchConfig{}, stdout: os.Stdout, stderr: os.Stderr}
+	flag.StringVar(&cfg.root, "root", "", "repository root (default: nearest parent with a go.mod)")
+	flag.StringVar(&cfg.baseURL, "url", "https://adventofcode.com", "base url used to fetch puzzle input")
+	flag.Func("inputs", "additional input root holding <year>/<dd>/ directories, may be repeated", func(s string) error {
+		cl.DefaultInputs.Roots = append(cl.DefaultInputs.Roots, s)
+		return nil
+	})
+	flag.IntVar(&cfg.jobs, "j", runtime.GOMAXPROCS(0), "number of days run at once by year all")
+	flag.DurationVar(&cfg.timeout, "timeout", time.Minute, "time limit of every case, 0 for none")
+	flag.BoolVar(&cl.Debug, "debug", false, "show the debug output of solvers")
+	flag.StringVar(&cl.VizDir, "viz", "", "directory solvers save pictures to, none are saved if empty")
+	flag.StringVar(&cfg.format, "format", "text", "result format: text, json or junit")
+	record := flag.Bool("record", false, "record the answer of every puzzle that has none in answers.json")
+	verify := flag.Bool("verify", false, "fail puzzles that have no answer in answers.json")
+	flag.BoolVar(&cfg.bench.enabled, "bench", false, "benchmark solutions instead of running them once")
+	flag.DurationVar(&cfg.bench.time, "benchtime", time.Second, "minimum time spent benchmarking each case")
+	flag.StringVar(&cfg.bench.baseline, "baseline", "", "compare benchmarks against this baseline file")
+	flag.BoolVar(&cfg.bench.update, "baseline-update", false, "write benchmark results to the baseline file")
+	flag.Float64Var(&cfg.bench.tolerance, "tolerance", 0.2, "relative median slowdown flagged as a regression")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	switch {
+	case *record && *verify:
+		fmt.Fprintln(os.Stderr, "error: -record and -verify are mutually exclusive")
+		os.Exit(2)
+	case *record:
+		cl.Answering = cl.RecordAnswers
+	case *verify:
+		cl.Answering = cl.VerifyAnswers
+	}
+	switch cfg.format {
+	case "text":
+		cfg.report.Out = os.Stdout
+	case "json", "junit":
+		// keep stdout clean for the encoded report
+		cfg.stdout = os.Stderr
+	default:
+		fmt.Fprintf(os.Stderr, "error: unknown format %q\n", cfg.format)
+		os.Exit(2)
+	}
+	err := cfg.bench.load()
+	if err == nil {
+		err = execute(cfg, flag.Args())
+	}
+	if err == nil {
+		err = errors.Join(writeReport(cfg), cfg.bench.finish())
+	}
+	if err != nil {
+		if errors.Is(err, errUsage) {
+			flag.Usage()
+		} else {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func writeReport(cfg config) error {
+	if len(cfg.report.Results) == 0 {
+		return nil
+	}
+	var err error
+	switch cfg.format {
+	case "text":
+		_, err = fmt.Fprintln(os.Stdout, cfg.report.Summary())
+	case "json":
+		err = cfg.report.WriteJSON(os.Stdout)
+	case "junit":
+		err = cfg.report.WriteJUnit(os.Stdout)
+	}
+	if err != nil {
+		return err
+	}
+	if n := cfg.report.Failed(); n > 0 {
+		return fmt.Errorf("%d failed", n)
+	}
+	return nil
+}
+
+func execute(cfg config, args []string) error {
+	if len(args) > 0 && args[0] == "list" {
+		return list(cfg, args[1:])
+	}
+	if len(args) < 2 {
+		return errUsage
+	}
+	if cfg.root == "" {
+		root, err := findRoot()
+		if err != nil {
+			return err
+		}
+		cfg.root = root
+	}
+	cl.DefaultInputs.Roots = append(cl.DefaultInputs.Roots, filepath.Join(cfg.root, "inputs"))
+	year, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid year %q", args[0])
+	}
+	if args[1] == "all" {
+		return runYear(cfg, year)
+	}
+	if len(args) < 3 {
+		return errUsage
+	}
+	day, err := strconv.Atoi(args[1])
+	if err != nil || day < 1 || day > 25 {
+		return fmt.Errorf("invalid day %q", args[1])
+	}
+	switch args[2] {
+	case "start":
+		return startDay(cfg, year, day)
+	case "run":
+		return runDay(cfg, year, day)
+	}
+	return errUsage
+}
+
+func list(cfg config, args []string) error {
+	if len(args) == 0 {
+		return listDays(cfg, 0)
+	}
+	year, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid year %q", args[0])
+	}
+	return listDays(cfg, year)
+}
+
+func findRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("could not find repository root, use -root")
+		}
+		dir = parent
+	}
+}
+
+func dayDir(root string, year, day int) string {
+	return filepath.Join(root, strconv.Itoa(year), fmt.Sprintf("%02d", day))
+}
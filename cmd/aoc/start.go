@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func startDay(cfg config, year, day int) error {
+	l, err := langFor(year)
+	if err != nil {
+		return err
+	}
+	dir := dayDir(cfg.root, year, day)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%d %02d already exists", year, day)
+	}
+	session, err := loadSession(cfg.root)
+	if err != nil {
+		return err
+	}
+	data, err := fetchInput(cfg.baseURL, session, year, day)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "puzzle.in"), data, 0o644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "example.in"), nil, 0o644); err != nil {
+		return err
+	}
+	if err := l.render(filepath.Join(dir, l.filename), year, day); err != nil {
+		return err
+	}
+	if l.filename == goFile {
+		return writeDays(cfg.root)
+	}
+	return nil
+}
+
+func fetchInput(baseURL, session string, year, day int) ([]byte, error) {
+	url := fmt.Sprintf("%s/%d/day/%d/input", strings.TrimSuffix(baseURL, "/"), year, day)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: session})
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to fetch puzzle input from %s (%d)", url, res.StatusCode)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// loadSession prefers AOC_SESSION from the environment and falls
+// back to the .env file in the repository root.
+func loadSession(root string) (string, error) {
+	if s := os.Getenv("AOC_SESSION"); s != "" {
+		return s, nil
+	}
+	errMissing := errors.New("please set AOC_SESSION in .env file")
+	f, err := os.Open(filepath.Join(root, ".env"))
+	if err != nil {
+		return "", errMissing
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		k, v, ok := strings.Cut(strings.TrimSpace(sc.Text()), "=")
+		if !ok || strings.TrimPrefix(k, "export ") != "AOC_SESSION" {
+			continue
+		}
+		if v = strings.Trim(v, `"'`); v != "" {
+			return v, nil
+		}
+	}
+	return "", errMissing
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// inputServer serves body as the input of every day to requests with
+// the session cookie abc, counting the requests in hits.
+func inputServer(t *testing.T, status int, body string, hits *int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*hits++
+		if c, err := r.Cookie("session"); err != nil || c.Value != "abc" {
+			http.Error(w, "no session", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/2024/day/5/input" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		status  int
+		want    string
+		err     string
+	}{
+		{"ok", "abc", http.StatusOK, "1 2 3\n", ""},
+		{"bad session", "xyz", http.StatusOK, "", "(400)"},
+		{"server error", "abc", http.StatusInternalServerError, "", "(500)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits int
+			srv := inputServer(t, tt.status, tt.want, &hits)
+			got, err := fetchInput(srv.URL+"/", tt.session, 2024, 5)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSession(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("AOC_SESSION", "")
+	if _, err := loadSession(root); err == nil {
+		t.Error("no error without a session")
+	}
+	env := "OTHER=1\nexport AOC_SESSION=\"fromfile\"\n"
+	if err := os.WriteFile(filepath.Join(root, ".env"), []byte(env), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := loadSession(root); err != nil || s != "fromfile" {
+		t.Errorf("got %q, %v, want fromfile", s, err)
+	}
+	t.Setenv("AOC_SESSION", "fromenv")
+	if s, _ := loadSession(root); s != "fromenv" {
+		t.Errorf("got %q, want the environment to win", s)
+	}
+}
+
+func TestStartDay(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "cmd", "aoc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AOC_SESSION", "abc")
+	var hits int
+	srv := inputServer(t, http.StatusOK, "input\n", &hits)
+	cfg := config{root: root, baseURL: srv.URL}
+	if err := startDay(cfg, 2024, 5); err != nil {
+		t.Fatal(err)
+	}
+	dir := dayDir(root, 2024, 5)
+	read := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	if got := read("puzzle.in"); got != "input\n" {
+		t.Errorf("puzzle.in is %q", got)
+	}
+	if got := read(goFile); !strings.Contains(got, "cl.NewDay(2024, 5,") {
+		t.Errorf("%s does not register the day:\n%s", goFile, got)
+	}
+	days, err := os.ReadFile(filepath.Join(root, "cmd", "aoc", "days.go"))
+	if err != nil || !strings.Contains(string(days), `"github.com/lindeneg/aoc/2024/05"`) {
+		t.Errorf("days.go does not import the day: %v\n%s", err, days)
+	}
+
+	// starting it again must neither fetch nor touch the input
+	if err := os.WriteFile(filepath.Join(dir, "puzzle.in"), []byte("edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := startDay(cfg, 2024, 5); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("error %v, want already exists", err)
+	}
+	if got := read("puzzle.in"); got != "edited\n" || hits != 1 {
+		t.Errorf("puzzle.in is %q after %d fetches", got, hits)
+	}
+}
+
+func TestStartDayFetchError(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("AOC_SESSION", "abc")
+	var hits int
+	srv := inputServer(t, http.StatusNotFound, "", &hits)
+	if err := startDay(config{root: root, baseURL: srv.URL}, 2024, 5); err == nil {
+		t.Fatal("no error for a failed fetch")
+	}
+	if _, err := os.Stat(dayDir(root, 2024, 5)); !os.IsNotExist(err) {
+		t.Errorf("day directory created after a failed fetch: %v", err)
+	}
+}
+
+func TestDayTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), goFile)
+	if err := golang.render(path, 2024, 5); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// part 2 is meant to be enabled by uncommenting it
+	uncommented := strings.ReplaceAll(string(data), "\t//\t", "\t")
+	for name, src := range map[string]string{"generated": string(data), "uncommented": uncommented} {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, goFile, src, 0)
+		if err == nil {
+			conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+			_, err = conf.Check("day05", fset, []*ast.File{f}, nil)
+		}
+		if err != nil {
+			t.Errorf("%s: %v\n%s", name, err, src)
+		}
+	}
+}